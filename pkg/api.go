@@ -1,6 +1,11 @@
 package pkg
 
 import (
+	"io"
+
+	"github.com/scagogogo/go-mod-parser/pkg/dirhash"
+	"github.com/scagogogo/go-mod-parser/pkg/gosum"
+	"github.com/scagogogo/go-mod-parser/pkg/gover"
 	"github.com/scagogogo/go-mod-parser/pkg/module"
 	"github.com/scagogogo/go-mod-parser/pkg/parser"
 )
@@ -15,6 +20,27 @@ func ParseGoModContent(content string) (*module.Module, error) {
 	return parser.ParseGoModContent(content)
 }
 
+// ParseWithOptions 按照指定选项从io.Reader解析go.mod文件
+// 开启Recover后会跳过无法解析的语句，返回部分解析的模块以及所有诊断信息
+func ParseWithOptions(r io.Reader, opts parser.Options) (*module.Module, error) {
+	return parser.ParseWithOptions(r, opts)
+}
+
+// ParseGoModFileSyntax 解析指定路径的go.mod文件，同时返回保留注释和位置信息的语法树
+func ParseGoModFileSyntax(path string) (*parser.File, error) {
+	return parser.ParseSyntaxFromFile(path)
+}
+
+// FormatGoMod 将模块信息格式化为规范的go.mod文本
+func FormatGoMod(mod *module.Module) []byte {
+	return parser.FormatModule(mod)
+}
+
+// FormatGoModWithOptions 按照指定选项将模块信息格式化为规范的go.mod文本
+func FormatGoModWithOptions(mod *module.Module, opts parser.FormatOptions) []byte {
+	return parser.FormatModuleWithOptions(mod, opts)
+}
+
 // FindAndParseGoModFile 在指定目录及其父目录中查找并解析go.mod文件
 func FindAndParseGoModFile(dir string) (*module.Module, error) {
 	return parser.FindAndParseGoModFile(dir)
@@ -25,8 +51,68 @@ func FindAndParseGoModInCurrentDir() (*module.Module, error) {
 	return parser.FindAndParseGoModInCurrentDir()
 }
 
+// ParseGoSumFile 解析指定路径的go.sum文件
+func ParseGoSumFile(path string) (*gosum.File, error) {
+	return gosum.ParseFromFile(path)
+}
+
+// FindAndParseGoSum 在指定目录及其父目录中查找go.mod文件，并解析与其位于同一目录的go.sum文件
+func FindAndParseGoSum(dir string) (*gosum.File, error) {
+	return gosum.FindAndParseGoSum(dir)
+}
+
+// CheckGoSum 检查go.sum是否与go.mod一致，报告缺少、过期和相互冲突的校验和
+func CheckGoSum(mod *module.Module, sum *gosum.File) *gosum.Report {
+	return gosum.Check(mod, sum)
+}
+
+// HashModuleDir 计算本地模块目录的 h1 校验和，结果可以与go.sum中模块完整内容的校验和比较
+func HashModuleDir(dir, path, version string) (string, error) {
+	return dirhash.HashModuleDir(dir, path, version)
+}
+
+// HashModuleZip 计算模块zip文件的 h1 校验和
+func HashModuleZip(r io.ReaderAt, size int64) (string, error) {
+	return dirhash.HashZip(r, size)
+}
+
+// HashGoMod 计算go.mod内容的 h1 校验和，结果可以与go.sum中 /go.mod 行的校验和比较
+func HashGoMod(data []byte) (string, error) {
+	return dirhash.HashGoMod(data)
+}
+
+// VerifyLocalReplace 检查本地目录替换的目标是否与go.sum中被替换模块版本的校验和一致
+func VerifyLocalReplace(mod *module.Module, sum *gosum.File, rep *module.Replace) (*gosum.Verification, error) {
+	return gosum.VerifyLocalReplace(mod, sum, rep)
+}
+
 // 以下是便捷函数，帮助用户检查和访问go.mod文件的不同部分
 
+// EffectiveToolchain 返回模块实际使用的Go工具链名称
+func EffectiveToolchain(mod *module.Module) string {
+	return parser.EffectiveToolchain(mod)
+}
+
+// EffectiveToolchainVersion 返回模块实际使用的Go工具链版本
+func EffectiveToolchainVersion(mod *module.Module) string {
+	return parser.EffectiveToolchainVersion(mod)
+}
+
+// SupportsFeature 检查模块的go版本是否支持某个取决于go版本的特性
+func SupportsFeature(mod *module.Module, feature gover.Feature) bool {
+	return parser.SupportsFeature(mod, feature)
+}
+
+// HasGodebug 检查模块是否有特定的godebug设置
+func HasGodebug(mod *module.Module, key string) bool {
+	return parser.HasGodebug(mod, key)
+}
+
+// GetGodebug 获取模块的特定godebug设置
+func GetGodebug(mod *module.Module, key string) *module.Godebug {
+	return parser.GetGodebug(mod, key)
+}
+
 // HasRequire 检查模块是否有特定的依赖
 func HasRequire(mod *module.Module, path string) bool {
 	return parser.HasRequire(mod, path)
@@ -37,6 +123,21 @@ func GetRequire(mod *module.Module, path string) *module.Require {
 	return parser.GetRequire(mod, path)
 }
 
+// GetIncompatibleRequires 返回所有使用+incompatible版本的依赖
+func GetIncompatibleRequires(mod *module.Module) []*module.Require {
+	return parser.GetIncompatibleRequires(mod)
+}
+
+// HasTool 检查模块是否声明了特定的工具
+func HasTool(mod *module.Module, path string) bool {
+	return parser.HasTool(mod, path)
+}
+
+// GetToolRequire 获取提供指定工具的依赖项
+func GetToolRequire(mod *module.Module, toolPath string) *module.Require {
+	return parser.GetToolRequire(mod, toolPath)
+}
+
 // HasReplace 检查模块是否有特定的替换规则
 func HasReplace(mod *module.Module, path string) bool {
 	return parser.HasReplace(mod, path)
@@ -56,3 +157,18 @@ func HasExclude(mod *module.Module, path, version string) bool {
 func HasRetract(mod *module.Module, version string) bool {
 	return parser.HasRetract(mod, version)
 }
+
+// GetRetract 获取撤回了特定版本的retract声明
+func GetRetract(mod *module.Module, version string) *module.Retract {
+	return parser.GetRetract(mod, version)
+}
+
+// CheckRetractions 检查每个已发布版本是否被撤回，以及撤回它的retract声明和理由
+func CheckRetractions(mod *module.Module, versions []string) []*parser.RetractStatus {
+	return parser.CheckRetractions(mod, versions)
+}
+
+// CheckLocalReplaces 检查模块中所有本地目录替换的目标目录是否存在并包含go.mod文件
+func CheckLocalReplaces(mod *module.Module) []*parser.LocalReplaceStatus {
+	return parser.CheckLocalReplaces(mod)
+}
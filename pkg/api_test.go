@@ -91,6 +91,26 @@ go 1.21
 	assert.Equal(t, "github.com/example/test", mod.Name)
 }
 
+func TestFindAndParseGoSum(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte("module github.com/example/test\n"), 0644))
+	goSumPath := filepath.Join(tempDir, "go.sum")
+	content := "github.com/stretchr/testify v1.8.4/go.mod h1:sz/lmYIOXD/1dqDmKjjqLyZ2RngseejIcXlSw2iwfAo=\n"
+	require.NoError(t, os.WriteFile(goSumPath, []byte(content), 0644))
+
+	sum, err := FindAndParseGoSum(tempDir)
+	require.NoError(t, err)
+	assert.True(t, sum.Has("github.com/stretchr/testify", "v1.8.4", true))
+
+	sum, err = ParseGoSumFile(goSumPath)
+	require.NoError(t, err)
+	assert.Len(t, sum.Entries, 1)
+
+	mod, err := ParseGoModContent("module github.com/example/test\n\nrequire github.com/stretchr/testify v1.8.4 // indirect\n")
+	require.NoError(t, err)
+	assert.True(t, CheckGoSum(mod, sum).OK())
+}
+
 func TestFindAndParseGoModFile_NotFound(t *testing.T) {
 	// 测试在没有go.mod的目录中查找
 	tempDir := t.TempDir()
@@ -172,4 +192,9 @@ retract v1.0.1 // security issue
 	// 测试HasRetract
 	assert.True(t, HasRetract(mod, "v1.0.1"))
 	assert.False(t, HasRetract(mod, "v1.0.0"))
+	assert.NotNil(t, GetRetract(mod, "v1.0.1"))
+
+	statuses := CheckRetractions(mod, []string{"v1.0.0", "v1.0.1"})
+	assert.False(t, statuses[0].Retracted())
+	assert.True(t, statuses[1].Retracted())
 }
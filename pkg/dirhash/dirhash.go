@@ -0,0 +1,257 @@
+// Package dirhash 实现go.sum使用的 h1 校验和算法
+//
+// h1 校验和的计算方式：将文件按名称排序，对每个文件的内容计算SHA-256，
+// 依次写入 "<十六进制摘要>  <文件名>\n" 形式的摘要行，再对摘要行整体计算SHA-256，
+// 结果使用标准base64编码并加上 "h1:" 前缀。
+//
+// 模块内容的校验和中文件名带有 "<模块路径>@<版本>/" 前缀；
+// go.sum中 /go.mod 行的校验和只包含一个名为 go.mod 的文件。
+package dirhash
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/go-mod-parser/pkg/gover"
+	"github.com/scagogogo/go-mod-parser/pkg/parser"
+)
+
+var (
+	// ErrNewlineInFileName 表示文件名中包含换行符，无法写入摘要行
+	ErrNewlineInFileName = errors.New("dirhash: filenames with newlines are not supported")
+	// ErrNotDirectory 表示要计算校验和的路径不是目录
+	ErrNotDirectory = errors.New("dirhash: not a directory")
+)
+
+// Hash1 计算文件列表的 h1 校验和，open用于按名称打开文件
+func Hash1(files []string, open func(string) (io.ReadCloser, error)) (string, error) {
+	h := sha256.New()
+	files = append([]string(nil), files...)
+	sort.Strings(files)
+	for _, file := range files {
+		if strings.Contains(file, "\n") {
+			return "", fmt.Errorf("%w: %q", ErrNewlineInFileName, file)
+		}
+		r, err := open(file)
+		if err != nil {
+			return "", err
+		}
+		hf := sha256.New()
+		_, err = io.Copy(hf, r)
+		r.Close()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%x  %s\n", hf.Sum(nil), file)
+	}
+	return "h1:" + base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+// HashDir 计算目录中所有文件的 h1 校验和，文件名为 prefix/相对路径，prefix通常为 <模块路径>@<版本>
+// 与go命令校验模块缓存目录的方式相同，不过滤任何文件
+func HashDir(dir, prefix string) (string, error) {
+	files, err := DirFiles(dir, prefix)
+	if err != nil {
+		return "", err
+	}
+	return Hash1(files, func(name string) (io.ReadCloser, error) {
+		return os.Open(filepath.Join(dir, strings.TrimPrefix(name, prefix)))
+	})
+}
+
+// DirFiles 返回目录中所有文件的名称，即 prefix/相对路径（使用 / 分隔），prefix为空时只有相对路径
+func DirFiles(dir, prefix string) ([]string, error) {
+	var files []string
+	dir = filepath.Clean(dir)
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if file == dir {
+			return fmt.Errorf("%w: %s", ErrNotDirectory, dir)
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(filepath.Join(prefix, rel)))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// HashModuleDir 计算本地模块目录对应的模块内容校验和，结果可以与go.sum中 path version 行比较
+// 与go命令打包模块时的规则一致，忽略版本控制目录（.git、.hg、.svn、.bzr）、
+// 包含go.mod的子目录（嵌套模块）、vendor目录中的包、.hg_archival.txt以及符号链接等非普通文件；
+// vendor目录的规则取决于根目录go.mod中的go版本
+func HashModuleDir(dir, path, version string) (string, error) {
+	files, err := moduleFiles(dir)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := path + "@" + version + "/"
+	names := make([]string, len(files))
+	byName := make(map[string]string, len(files))
+	for i, rel := range files {
+		names[i] = prefix + rel
+		byName[names[i]] = filepath.Join(dir, filepath.FromSlash(rel))
+	}
+	return Hash1(names, func(name string) (io.ReadCloser, error) {
+		return os.Open(byName[name])
+	})
+}
+
+// HashZip 计算模块zip文件的 h1 校验和，zip中的文件名应带有 "<模块路径>@<版本>/" 前缀
+func HashZip(r io.ReaderAt, size int64) (string, error) {
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return "", err
+	}
+
+	var files []string
+	byName := make(map[string]*zip.File)
+	for _, file := range z.File {
+		if strings.HasSuffix(file.Name, "/") {
+			continue
+		}
+		files = append(files, file.Name)
+		byName[file.Name] = file
+	}
+	return Hash1(files, func(name string) (io.ReadCloser, error) {
+		f := byName[name]
+		if f == nil {
+			return nil, fmt.Errorf("file %q not found in zip", name)
+		}
+		return f.Open()
+	})
+}
+
+// HashZipFile 计算模块zip文件的 h1 校验和
+func HashZipFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	return HashZip(f, info.Size())
+}
+
+// HashGoMod 计算go.mod内容的 h1 校验和，结果可以与go.sum中 path version/go.mod 行比较
+func HashGoMod(data []byte) (string, error) {
+	return Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	})
+}
+
+// HashGoModFile 计算go.mod文件的 h1 校验和
+func HashGoModFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return HashGoMod(data)
+}
+
+// moduleFiles 返回模块目录中会被打包进模块zip的文件，使用相对目录的 / 分隔路径
+func moduleFiles(dir string) ([]string, error) {
+	var files []string
+	dir = filepath.Clean(dir)
+	vers := goModVersion(filepath.Join(dir, "go.mod"))
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if file == dir {
+			if !d.IsDir() {
+				return fmt.Errorf("%w: %s", ErrNotDirectory, dir)
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			switch d.Name() {
+			case ".bzr", ".git", ".hg", ".svn":
+				return filepath.SkipDir
+			}
+			if info, err := os.Lstat(filepath.Join(file, "go.mod")); err == nil && !info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// .hg_archival.txt 由 hg archive 生成，go命令总是忽略该文件
+		if !d.Type().IsRegular() || isVendoredPackage(rel, vers) || rel == ".hg_archival.txt" {
+			return nil
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// goModVersion 返回go.mod中go声明的语言版本，文件不存在或没有go声明时返回空字符串
+// 与go命令一样宽松解析，忽略go.mod中的其他错误
+func goModVersion(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	mod, _ := parser.ParseWithOptions(bytes.NewReader(data), parser.Options{FileName: path, Recover: true})
+	if mod == nil {
+		return ""
+	}
+	return gover.Lang(mod.GoVersion)
+}
+
+// isVendoredPackage 检查文件是否属于vendor目录中的包，vers为根目录go.mod中go声明的语言版本
+// 与go命令的规则一致：go 1.24之前保留vendor/modules.txt，并沿用嵌套vendor目录偏移量的错误
+// （golang.org/issue/37397），以保证已有模块的校验和不变
+func isVendoredPackage(name, vers string) bool {
+	go124 := gover.IsValid(vers) && gover.Compare(vers, "1.24") >= 0
+	if go124 && name == "vendor/modules.txt" {
+		return true
+	}
+	var i int
+	if strings.HasPrefix(name, "vendor/") {
+		i += len("vendor/")
+	} else if j := strings.Index(name, "/vendor/"); j >= 0 {
+		if go124 {
+			i = j + len("/vendor/")
+		} else {
+			i += len("/vendor/")
+		}
+	} else {
+		return false
+	}
+	return strings.Contains(name[i:], "/")
+}
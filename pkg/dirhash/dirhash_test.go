@@ -0,0 +1,301 @@
+package dirhash
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testGoMod = "module example.com/m\n\ngo 1.21\n"
+	testGoSrc = "package m\n"
+
+	// 由sha256sum和base64命令独立计算得到
+	wantModuleHash = "h1:fMmDKxylWHrLDMBXPo7zB62VLWneCU9/dHwc0CJrWDU="
+	wantGoModHash  = "h1:ONeDgCa5UF/jJRjGzpOKmUiezgFEk4IPFZ96frvroW0="
+)
+
+// writeFiles 在临时目录中创建文件，返回目录路径
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestHash1(t *testing.T) {
+	open := func(name string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(map[string]string{
+			"example.com/m@v1.0.0/go.mod": testGoMod,
+			"example.com/m@v1.0.0/m.go":   testGoSrc,
+		}[name])), nil
+	}
+
+	// 文件顺序不影响结果
+	h, err := Hash1([]string{"example.com/m@v1.0.0/m.go", "example.com/m@v1.0.0/go.mod"}, open)
+	if err != nil {
+		t.Fatalf("Hash1() error = %v", err)
+	}
+	if h != wantModuleHash {
+		t.Errorf("Hash1() = %s, want %s", h, wantModuleHash)
+	}
+
+	if _, err := Hash1([]string{"bad\nname"}, open); !errors.Is(err, ErrNewlineInFileName) {
+		t.Errorf("Hash1() error = %v, want ErrNewlineInFileName", err)
+	}
+
+	openErr := errors.New("open failed")
+	_, err = Hash1([]string{"a"}, func(string) (io.ReadCloser, error) { return nil, openErr })
+	if !errors.Is(err, openErr) {
+		t.Errorf("Hash1() error = %v, want %v", err, openErr)
+	}
+}
+
+func TestHashGoMod(t *testing.T) {
+	h, err := HashGoMod([]byte(testGoMod))
+	if err != nil {
+		t.Fatalf("HashGoMod() error = %v", err)
+	}
+	if h != wantGoModHash {
+		t.Errorf("HashGoMod() = %s, want %s", h, wantGoModHash)
+	}
+
+	dir := writeFiles(t, map[string]string{"go.mod": testGoMod})
+	h, err = HashGoModFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatalf("HashGoModFile() error = %v", err)
+	}
+	if h != wantGoModHash {
+		t.Errorf("HashGoModFile() = %s, want %s", h, wantGoModHash)
+	}
+}
+
+func TestHashDir(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"go.mod": testGoMod, "m.go": testGoSrc})
+
+	h, err := HashDir(dir, "example.com/m@v1.0.0")
+	if err != nil {
+		t.Fatalf("HashDir() error = %v", err)
+	}
+	if h != wantModuleHash {
+		t.Errorf("HashDir() = %s, want %s", h, wantModuleHash)
+	}
+
+	files, err := DirFiles(dir, "")
+	if err != nil {
+		t.Fatalf("DirFiles() error = %v", err)
+	}
+	if len(files) != 2 || files[0] != "go.mod" || files[1] != "m.go" {
+		t.Errorf("DirFiles() = %v", files)
+	}
+
+	if _, err := HashDir(filepath.Join(dir, "m.go"), ""); !errors.Is(err, ErrNotDirectory) {
+		t.Errorf("HashDir() on file error = %v, want ErrNotDirectory", err)
+	}
+}
+
+func TestHashModuleDir(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"go.mod":               testGoMod,
+		"m.go":                 testGoSrc,
+		".git/HEAD":            "ref: refs/heads/main\n",
+		"sub/go.mod":           "module example.com/m/sub\n",
+		"sub/sub.go":           "package sub\n",
+		"vendor/modules.txt":   "# example.com/dep v1.0.0\n",
+		"vendor/example.com/x": "package x\n",
+	})
+	if err := os.Symlink("m.go", filepath.Join(dir, "link.go")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	// 只有go.mod、m.go和vendor/modules.txt会被打包进模块zip
+	want, err := Hash1([]string{
+		"example.com/m@v1.0.0/go.mod",
+		"example.com/m@v1.0.0/m.go",
+		"example.com/m@v1.0.0/vendor/modules.txt",
+	}, func(name string) (io.ReadCloser, error) {
+		return os.Open(filepath.Join(dir, strings.TrimPrefix(name, "example.com/m@v1.0.0/")))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := HashModuleDir(dir, "example.com/m", "v1.0.0")
+	if err != nil {
+		t.Fatalf("HashModuleDir() error = %v", err)
+	}
+	if h != want {
+		t.Errorf("HashModuleDir() = %s, want %s", h, want)
+	}
+
+	clean := writeFiles(t, map[string]string{"go.mod": testGoMod, "m.go": testGoSrc})
+	h, err = HashModuleDir(clean, "example.com/m", "v1.0.0")
+	if err != nil {
+		t.Fatalf("HashModuleDir() error = %v", err)
+	}
+	if h != wantModuleHash {
+		t.Errorf("HashModuleDir() = %s, want %s", h, wantModuleHash)
+	}
+}
+
+func TestHashZip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range []struct{ name, content string }{
+		{"example.com/m@v1.0.0/m.go", testGoSrc},
+		{"example.com/m@v1.0.0/go.mod", testGoMod},
+	} {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(file.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := HashZip(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("HashZip() error = %v", err)
+	}
+	if h != wantModuleHash {
+		t.Errorf("HashZip() = %s, want %s", h, wantModuleHash)
+	}
+
+	path := filepath.Join(t.TempDir(), "v1.0.0.zip")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h, err = HashZipFile(path)
+	if err != nil {
+		t.Fatalf("HashZipFile() error = %v", err)
+	}
+	if h != wantModuleHash {
+		t.Errorf("HashZipFile() = %s, want %s", h, wantModuleHash)
+	}
+
+	if _, err := HashZip(strings.NewReader("not a zip"), 9); err == nil {
+		t.Error("HashZip() expected error for invalid zip")
+	}
+}
+
+func TestHashModuleDir_GoVersionRules(t *testing.T) {
+	const goMod121 = "module example.com/m\n\ngo 1.21\n"
+	const goMod124 = "module example.com/m\n\ngo 1.24\n"
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name: "nested vendor before go 1.24",
+			files: map[string]string{
+				"go.mod":                goMod121,
+				"pkg/vendor/vendor.go":  "package vendor\n",
+				"pkg/vendor/foo/foo.go": "package foo\n",
+			},
+			want: []string{"go.mod"},
+		},
+		{
+			name: "nested vendor since go 1.24",
+			files: map[string]string{
+				"go.mod":                goMod124,
+				"pkg/vendor/vendor.go":  "package vendor\n",
+				"pkg/vendor/foo/foo.go": "package foo\n",
+			},
+			want: []string{"go.mod", "pkg/vendor/vendor.go"},
+		},
+		{
+			name: "vendor/modules.txt before go 1.24",
+			files: map[string]string{
+				"go.mod":             goMod121,
+				"vendor/modules.txt": "# example.com/dep v1.0.0\n",
+			},
+			want: []string{"go.mod", "vendor/modules.txt"},
+		},
+		{
+			name: "vendor/modules.txt since go 1.24",
+			files: map[string]string{
+				"go.mod":             goMod124,
+				"vendor/modules.txt": "# example.com/dep v1.0.0\n",
+			},
+			want: []string{"go.mod"},
+		},
+		{
+			name: "hg archival file",
+			files: map[string]string{
+				"go.mod":               goMod121,
+				".hg_archival.txt":     "repo: 0123456789abcdef\n",
+				"sub/.hg_archival.txt": "kept\n",
+			},
+			want: []string{"go.mod", "sub/.hg_archival.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+
+			names := make([]string, len(tt.want))
+			for i, name := range tt.want {
+				names[i] = "example.com/m@v1.0.0/" + name
+			}
+			want, err := Hash1(names, func(name string) (io.ReadCloser, error) {
+				return os.Open(filepath.Join(dir, strings.TrimPrefix(name, "example.com/m@v1.0.0/")))
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h, err := HashModuleDir(dir, "example.com/m", "v1.0.0")
+			if err != nil {
+				t.Fatalf("HashModuleDir() error = %v", err)
+			}
+			if h != want {
+				t.Errorf("HashModuleDir() = %s, want hash of %v", h, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsVendoredPackage(t *testing.T) {
+	tests := []struct {
+		name string
+		vers string
+		want bool
+	}{
+		{"vendor/modules.txt", "1.21", false},
+		{"vendor/modules.txt", "", false},
+		{"vendor/modules.txt", "1.24", true},
+		{"vendor/example.com/x/x.go", "1.21", true},
+		{"vendor/example.com/x/x.go", "1.24", true},
+		{"a/vendor/b/c.go", "1.21", true},
+		{"a/vendor/b/c.go", "1.24", true},
+		{"pkg/vendor/vendor.go", "1.21", true},
+		{"pkg/vendor/vendor.go", "1.24", false},
+		{"vendors/x/y.go", "1.24", false},
+		{"m.go", "1.21", false},
+	}
+	for _, tt := range tests {
+		if got := isVendoredPackage(tt.name, tt.vers); got != tt.want {
+			t.Errorf("isVendoredPackage(%q, %q) = %v, want %v", tt.name, tt.vers, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package gosum
+
+import (
+	"strings"
+
+	"github.com/scagogogo/go-mod-parser/pkg/gover"
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/scagogogo/go-mod-parser/pkg/parser"
+)
+
+// MissingHash 表示go.sum中缺少的一条校验和
+type MissingHash struct {
+	// Require 需要该校验和的require声明
+	Require *module.Require
+
+	// Path 需要校验和的模块路径，依赖被替换为其他模块时为替换目标的路径
+	Path string
+
+	// Version 需要校验和的模块版本，依赖被替换为其他模块时为替换目标的版本
+	Version string
+}
+
+// Conflict 表示同一模块版本的同一种校验和在go.sum中有多个不同的值
+type Conflict struct {
+	// Path 模块路径
+	Path string
+
+	// Version 模块版本
+	Version string
+
+	// GoMod 是否为go.mod校验和
+	GoMod bool
+
+	// Entries 相互冲突的记录，按在文件中出现的顺序排列
+	Entries []*Entry
+}
+
+// Report 表示go.mod与go.sum的一致性检查结果
+type Report struct {
+	// MissingGoModHashes 缺少go.mod校验和的依赖
+	MissingGoModHashes []*MissingHash
+
+	// MissingContentHashes 缺少完整内容校验和的直接依赖
+	MissingContentHashes []*MissingHash
+
+	// Stale 过期的完整内容校验和：记录的模块版本已不再被go.mod引用
+	Stale []*Entry
+
+	// Conflicts 同一模块版本有多个不同校验和的记录
+	Conflicts []*Conflict
+}
+
+// OK 检查go.mod与go.sum是否一致
+func (r *Report) OK() bool {
+	return len(r.MissingGoModHashes) == 0 && len(r.MissingContentHashes) == 0 &&
+		len(r.Stale) == 0 && len(r.Conflicts) == 0
+}
+
+// Check 检查go.sum是否与go.mod一致，可以发现手动修改go.mod后没有运行 go mod tidy 的情况：
+//
+//   - 每个依赖都需要go.mod校验和，直接依赖还需要完整内容校验和；
+//     间接依赖可能只为模块图提供go.mod，不要求完整内容校验和
+//   - 依赖被替换为其他模块时检查替换目标的校验和，被替换为本地目录时不需要校验和
+//   - go.mod引用的模块的其他版本的完整内容校验和视为过期；go 1.17及以后的go.mod列出了提供包的所有模块，
+//     不再被引用的模块的完整内容校验和也视为过期，但路径与被引用模块互为前缀的模块除外，
+//     go mod tidy 保留它们的校验和以排除有歧义的导入
+//   - go 1.17之前的go.mod不列出间接依赖，不再被引用的模块可能仍在模块图中提供包，
+//     无法只根据go.mod判断，不视为过期
+//   - go.mod校验和只用于构建模块图，判断是否过期需要完整的模块图，总是不视为过期
+//   - 同一模块版本的同一种校验和出现多个不同的值时视为冲突
+func Check(mod *module.Module, sum *File) *Report {
+	report := &Report{
+		MissingGoModHashes:   make([]*MissingHash, 0),
+		MissingContentHashes: make([]*MissingHash, 0),
+		Stale:                make([]*Entry, 0),
+		Conflicts:            make([]*Conflict, 0),
+	}
+
+	// referenced 记录go.mod引用的每个模块路径使用的版本
+	referenced := make(map[string]map[string]bool)
+	reference := func(path, version string) {
+		if referenced[path] == nil {
+			referenced[path] = make(map[string]bool)
+		}
+		referenced[path][version] = true
+	}
+
+	for _, req := range mod.Requires {
+		reference(req.Path, req.Version)
+
+		path, version := req.Path, req.Version
+		if rep := replacement(mod, req.Path, req.Version); rep != nil {
+			if rep.IsLocal() {
+				continue
+			}
+			path, version = rep.New.Path, rep.New.Version
+			reference(path, version)
+		}
+
+		missing := &MissingHash{Require: req, Path: path, Version: version}
+		if !sum.Has(path, version, true) {
+			report.MissingGoModHashes = append(report.MissingGoModHashes, missing)
+		}
+		if !req.Indirect && !sum.Has(path, version, false) {
+			report.MissingContentHashes = append(report.MissingContentHashes, missing)
+		}
+	}
+
+	pruned := parser.SupportsFeature(mod, gover.FeatureLazyLoading)
+	conflicts := make(map[string]*Conflict)
+	for _, e := range sum.Entries {
+		if !e.GoMod && staleContent(e, referenced, pruned) {
+			report.Stale = append(report.Stale, e)
+		}
+
+		key := e.Path + " " + e.Version
+		if e.GoMod {
+			key += GoModSuffix
+		}
+		conflict, ok := conflicts[key]
+		if !ok {
+			conflict = &Conflict{Path: e.Path, Version: e.Version, GoMod: e.GoMod}
+			conflicts[key] = conflict
+		}
+		conflict.Entries = append(conflict.Entries, e)
+		if len(conflict.Entries) == 2 {
+			report.Conflicts = append(report.Conflicts, conflict)
+		}
+	}
+
+	return report
+}
+
+// staleContent 检查完整内容校验和是否过期，referenced为go.mod引用的模块路径及其版本，
+// pruned表示go.mod是否列出了提供包的所有模块
+func staleContent(e *Entry, referenced map[string]map[string]bool, pruned bool) bool {
+	if versions, ok := referenced[e.Path]; ok {
+		return !versions[e.Version]
+	}
+	if !pruned {
+		return false
+	}
+	for path := range referenced {
+		if hasPathPrefix(path, e.Path) || hasPathPrefix(e.Path, path) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasPathPrefix 检查模块路径是否以prefix为前缀，按路径元素比较
+func hasPathPrefix(path, prefix string) bool {
+	return strings.HasPrefix(path, prefix) && (len(path) == len(prefix) || path[len(prefix)] == '/')
+}
+
+// replacement 返回对模块版本生效的replace声明：指定了版本的替换优先于没有指定版本的替换
+func replacement(mod *module.Module, path, version string) *module.Replace {
+	var found *module.Replace
+	for _, rep := range mod.Replaces {
+		if rep.Old.Path != path {
+			continue
+		}
+		if rep.Old.Version == version {
+			return rep
+		}
+		if rep.Old.Version == "" && found == nil {
+			found = rep
+		}
+	}
+	return found
+}
@@ -0,0 +1,119 @@
+package gosum
+
+import (
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/parser"
+)
+
+func TestCheck(t *testing.T) {
+	mod, err := parser.ParseFromString(`module github.com/example/app
+
+go 1.21
+
+require (
+	github.com/stretchr/testify v1.8.4
+	github.com/example/direct v1.2.0
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+	github.com/example/local v1.0.0
+	github.com/example/forked v1.0.0
+)
+
+replace github.com/example/local => ../local
+
+replace github.com/example/forked => github.com/fork/forked v1.1.0
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse go.mod: %v", err)
+	}
+
+	sum, err := ParseFromString(`github.com/davecgh/go-spew v1.1.0/go.mod h1:J7Y8YcW2NihsgmVo/mv3lAwl/skON4iLHjSsI+c5H38=
+github.com/davecgh/go-spew v1.1.1/go.mod h1:J7Y8YcW2NihsgmVo/mv3lAwl/skON4iLHjSsI+c5H38=
+github.com/example/direct v1.1.0 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=
+github.com/example/direct v1.1.0/go.mod h1:K4uyk7z7BCEPqu6E+C64Yfv1cQ7kz7rIZviUmN+EgEM=
+github.com/example/direct/nested v1.0.0 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=
+github.com/fork/forked v1.1.0 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=
+github.com/fork/forked v1.1.0/go.mod h1:K4uyk7z7BCEPqu6E+C64Yfv1cQ7kz7rIZviUmN+EgEM=
+github.com/stretchr/testify v1.8.4 h1:CcVxjf3Q8PM0mHUKJCdn+eZZtm5yQwehR5yeSVQQcUk=
+github.com/stretchr/testify v1.8.4/go.mod h1:sz/lmYIOXD/1dqDmKjjqLyZ2RngseejIcXlSw2iwfAo=
+github.com/stretchr/testify v1.8.4/go.mod h1:K4uyk7z7BCEPqu6E+C64Yfv1cQ7kz7rIZviUmN+EgEM=
+github.com/unrelated/mod v1.0.0 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=
+github.com/unrelated/mod v1.0.0/go.mod h1:K4uyk7z7BCEPqu6E+C64Yfv1cQ7kz7rIZviUmN+EgEM=
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse go.sum: %v", err)
+	}
+
+	report := Check(mod, sum)
+	if report.OK() {
+		t.Fatal("Expected problems to be reported")
+	}
+
+	// 间接依赖yaml.v3缺少go.mod校验和，直接依赖direct在go.sum中仍是旧版本
+	if len(report.MissingGoModHashes) != 2 ||
+		report.MissingGoModHashes[0].Path != "github.com/example/direct" ||
+		report.MissingGoModHashes[1].Path != "gopkg.in/yaml.v3" {
+		t.Errorf("Unexpected missing go.mod hashes: %+v", report.MissingGoModHashes)
+	}
+	if len(report.MissingContentHashes) != 1 ||
+		report.MissingContentHashes[0].Path != "github.com/example/direct" ||
+		report.MissingContentHashes[0].Version != "v1.2.0" {
+		t.Errorf("Unexpected missing content hashes: %+v", report.MissingContentHashes)
+	}
+
+	// 旧版本和不再被引用的模块的完整内容校验和过期；go.mod校验和用于构建模块图，
+	// 与被引用模块路径互为前缀的模块可能用于排除有歧义的导入，都不视为过期
+	if len(report.Stale) != 2 ||
+		report.Stale[0].String() != "github.com/example/direct v1.1.0 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=" ||
+		report.Stale[1].String() != "github.com/unrelated/mod v1.0.0 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=" {
+		t.Errorf("Unexpected stale entries: %v", report.Stale)
+	}
+
+	// go 1.17之前的go.mod不列出间接依赖，无法判断不再被引用的模块是否过期
+	mod.GoVersion = "1.16"
+	report = Check(mod, sum)
+	if len(report.Stale) != 1 || report.Stale[0].Path != "github.com/example/direct" {
+		t.Errorf("Unexpected stale entries before go 1.17: %v", report.Stale)
+	}
+
+	if len(report.Conflicts) != 1 {
+		t.Fatalf("Expected one conflict, got %+v", report.Conflicts)
+	}
+	conflict := report.Conflicts[0]
+	if conflict.Path != "github.com/stretchr/testify" || conflict.Version != "v1.8.4" || !conflict.GoMod ||
+		len(conflict.Entries) != 2 || conflict.Entries[0].Line != 9 || conflict.Entries[1].Line != 10 {
+		t.Errorf("Unexpected conflict: %+v", conflict)
+	}
+}
+
+func TestCheck_OK(t *testing.T) {
+	mod, err := parser.ParseFromString(`module github.com/example/app
+
+require (
+	github.com/stretchr/testify v1.8.4
+	github.com/example/forked v1.0.0
+)
+
+replace github.com/example/forked v1.0.0 => github.com/fork/forked v1.1.0
+
+replace github.com/example/forked => ../forked
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse go.mod: %v", err)
+	}
+
+	// 指定版本的替换优先于不指定版本的替换
+	sum, err := ParseFromString(`github.com/fork/forked v1.1.0 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=
+github.com/fork/forked v1.1.0/go.mod h1:K4uyk7z7BCEPqu6E+C64Yfv1cQ7kz7rIZviUmN+EgEM=
+github.com/stretchr/testify v1.8.4 h1:CcVxjf3Q8PM0mHUKJCdn+eZZtm5yQwehR5yeSVQQcUk=
+github.com/stretchr/testify v1.8.4/go.mod h1:sz/lmYIOXD/1dqDmKjjqLyZ2RngseejIcXlSw2iwfAo=
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse go.sum: %v", err)
+	}
+
+	if report := Check(mod, sum); !report.OK() {
+		t.Errorf("Expected consistent go.mod and go.sum, got %+v", report)
+	}
+}
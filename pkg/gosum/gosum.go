@@ -0,0 +1,109 @@
+// Package gosum 解析与go.mod位于同一目录的go.sum文件
+//
+// go.sum的每一行记录一个模块版本的校验和，格式为
+//
+//	<模块路径> <版本>[/go.mod] <算法>:<摘要>
+//
+// 例如
+//
+//	github.com/stretchr/testify v1.8.4 h1:CcVxjf3Q8PM0mHUKJCdn+eZZtm5yQwehR5yeSVQQcUk=
+//	github.com/stretchr/testify v1.8.4/go.mod h1:sz/lmYIOXD/1dqDmKjjqLyZ2RngseejIcXlSw2iwfAo=
+//
+// 带 /go.mod 后缀的行是模块go.mod文件的校验和，其余行是模块完整内容的校验和。
+package gosum
+
+import (
+	"errors"
+)
+
+var (
+	// ErrMalformedLine 表示go.sum中的一行不是 路径 版本 校验和 的形式
+	ErrMalformedLine = errors.New("malformed go.sum line")
+	// ErrInvalidVersion 表示go.sum中的版本不是合法的语义化版本
+	ErrInvalidVersion = errors.New("invalid go.sum version")
+	// ErrInvalidHash 表示go.sum中的校验和不是 算法:摘要 的形式，或h1摘要不是合法的SHA-256 base64编码
+	ErrInvalidHash = errors.New("invalid go.sum hash")
+	// ErrDuplicateLine 表示go.sum中同一条记录出现了多次
+	ErrDuplicateLine = errors.New("duplicate go.sum line")
+)
+
+// GoModSuffix go.sum中go.mod校验和的版本后缀
+const GoModSuffix = "/go.mod"
+
+// Entry 表示go.sum中的一条记录
+type Entry struct {
+	// Path 模块路径
+	Path string
+
+	// Version 模块版本，不包含 /go.mod 后缀
+	Version string
+
+	// GoMod 是否为模块go.mod文件的校验和，false表示模块完整内容的校验和
+	GoMod bool
+
+	// Algorithm 校验和算法，目前go命令只使用 h1
+	Algorithm string
+
+	// Digest 校验和摘要，h1为SHA-256的标准base64编码
+	Digest string
+
+	// Line 记录所在的行号，从1开始；不是从文件解析得到的记录为0
+	Line int
+}
+
+// Hash 返回 算法:摘要 形式的校验和，例如 h1:CcVxjf3Q...
+func (e *Entry) Hash() string {
+	return e.Algorithm + ":" + e.Digest
+}
+
+// String 返回记录在go.sum中的文本形式
+func (e *Entry) String() string {
+	version := e.Version
+	if e.GoMod {
+		version += GoModSuffix
+	}
+	return e.Path + " " + version + " " + e.Hash()
+}
+
+// File 表示解析后的go.sum文件
+type File struct {
+	// Entries 所有记录，按在文件中出现的顺序排列
+	Entries []*Entry
+}
+
+// Lookup 返回模块版本的所有记录，包括go.mod校验和与完整内容校验和
+func (f *File) Lookup(path, version string) []*Entry {
+	var entries []*Entry
+	for _, e := range f.Entries {
+		if e.Path == path && e.Version == version {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Hashes 返回模块版本的go.mod校验和（goMod为true）或完整内容校验和，通常只有一个
+func (f *File) Hashes(path, version string, goMod bool) []string {
+	var hashes []string
+	for _, e := range f.Lookup(path, version) {
+		if e.GoMod == goMod {
+			hashes = append(hashes, e.Hash())
+		}
+	}
+	return hashes
+}
+
+// Has 检查是否记录了模块版本的go.mod校验和（goMod为true）或完整内容校验和
+func (f *File) Has(path, version string, goMod bool) bool {
+	return len(f.Hashes(path, version, goMod)) > 0
+}
+
+// Bytes 返回go.sum的文本形式，每条记录一行
+func (f *File) Bytes() []byte {
+	var b []byte
+	for _, e := range f.Entries {
+		b = append(b, e.String()...)
+		b = append(b, '\n')
+	}
+	return b
+}
@@ -0,0 +1,148 @@
+package gosum
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/parser"
+	"github.com/scagogogo/go-mod-parser/pkg/utils"
+)
+
+const testGoSum = `github.com/davecgh/go-spew v1.1.1 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=
+github.com/davecgh/go-spew v1.1.1/go.mod h1:J7Y8YcW2NihsgmVo/mv3lAwl/skON4iLHjSsI+c5H38=
+
+github.com/stretchr/testify v1.8.4 h1:CcVxjf3Q8PM0mHUKJCdn+eZZtm5yQwehR5yeSVQQcUk=
+github.com/stretchr/testify v1.8.4/go.mod h1:sz/lmYIOXD/1dqDmKjjqLyZ2RngseejIcXlSw2iwfAo=
+gopkg.in/yaml.v3 v3.0.1/go.mod h1:K4uyk7z7BCEPqu6E+C64Yfv1cQ7kz7rIZviUmN+EgEM=
+`
+
+func TestParseFromString(t *testing.T) {
+	f, err := ParseFromString(testGoSum)
+	if err != nil {
+		t.Fatalf("ParseFromString failed: %v", err)
+	}
+	if len(f.Entries) != 5 {
+		t.Fatalf("Expected 5 entries, got %d", len(f.Entries))
+	}
+
+	e := f.Entries[3]
+	if e.Path != "github.com/stretchr/testify" || e.Version != "v1.8.4" || !e.GoMod ||
+		e.Algorithm != "h1" || e.Digest != "sz/lmYIOXD/1dqDmKjjqLyZ2RngseejIcXlSw2iwfAo=" || e.Line != 5 {
+		t.Errorf("Unexpected entry: %+v", e)
+	}
+	if e.Hash() != "h1:sz/lmYIOXD/1dqDmKjjqLyZ2RngseejIcXlSw2iwfAo=" {
+		t.Errorf("Unexpected hash: %s", e.Hash())
+	}
+	if e.String() != "github.com/stretchr/testify v1.8.4/go.mod h1:sz/lmYIOXD/1dqDmKjjqLyZ2RngseejIcXlSw2iwfAo=" {
+		t.Errorf("Unexpected string: %s", e.String())
+	}
+
+	if len(f.Lookup("github.com/stretchr/testify", "v1.8.4")) != 2 {
+		t.Error("Expected two entries for testify")
+	}
+	if !f.Has("gopkg.in/yaml.v3", "v3.0.1", true) || f.Has("gopkg.in/yaml.v3", "v3.0.1", false) {
+		t.Error("Expected only the go.mod hash for yaml.v3")
+	}
+	if got := f.Hashes("github.com/davecgh/go-spew", "v1.1.1", false); len(got) != 1 || got[0] != "h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=" {
+		t.Errorf("Unexpected hashes: %v", got)
+	}
+
+	// 去掉空行后与原文一致
+	if string(f.Bytes()) != strings.Replace(testGoSum, "\n\n", "\n", 1) {
+		t.Errorf("Unexpected Bytes output:\n%s", f.Bytes())
+	}
+}
+
+func TestParseWithOptions_Errors(t *testing.T) {
+	content := "github.com/a/b v1.0.0 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=\r\n" +
+		"github.com/a/b v1.0.0\n" +
+		"github.com/a/b 1.0.0 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=\n" +
+		"github.com/a/b v1.0.0/go.mod vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=\n" +
+		"github.com/a/b v1.0.0/go.mod h1:notbase64!\n" +
+		"github.com/a/b v1.0.0/go.mod h1:c2hvcnQ=\n" +
+		"  github.com/a/b   v1.0.0   h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=\n" +
+		"github.com/a/b v1.0.0/go.mod h2:anything\n"
+
+	// 默认在第一个错误处停止
+	f, err := ParseWithOptions(strings.NewReader(content), Options{FileName: "go.sum"})
+	if f != nil {
+		t.Error("Expected nil file without Recover")
+	}
+	var parseErr *parser.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 || parseErr.File != "go.sum" || !errors.Is(err, ErrMalformedLine) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	f, err = ParseWithOptions(strings.NewReader(content), Options{Recover: true})
+	var list parser.ErrorList
+	if !errors.As(err, &list) {
+		t.Fatalf("Expected ErrorList, got %v", err)
+	}
+
+	expected := []struct {
+		line   int
+		column int
+		text   string
+		err    error
+	}{
+		{2, 1, "github.com/a/b v1.0.0", ErrMalformedLine},
+		{3, 16, "1.0.0", ErrInvalidVersion},
+		{4, 30, "vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=", ErrInvalidHash},
+		{5, 30, "h1:notbase64!", ErrInvalidHash},
+		{6, 30, "h1:c2hvcnQ=", ErrInvalidHash},
+		{7, 3, "github.com/a/b   v1.0.0   h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=", ErrDuplicateLine},
+	}
+	if len(list) != len(expected) {
+		t.Fatalf("Expected %d errors, got %v", len(expected), list)
+	}
+	for i, want := range expected {
+		got := list[i]
+		if got.Line != want.line || got.Column != want.column || got.Text != want.text || !errors.Is(got, want.err) {
+			t.Errorf("Error %d = %+v, want line %d column %d text %q (%v)", i, got, want.line, want.column, want.text, want.err)
+		}
+	}
+	if !strings.Contains(list[5].Error(), "first seen on line 1") {
+		t.Errorf("Expected duplicate error to mention the first line, got %v", list[5])
+	}
+
+	// 其他算法的摘要不检查编码
+	if len(f.Entries) != 2 || f.Entries[1].Algorithm != "h2" {
+		t.Errorf("Unexpected entries: %v", f.Entries)
+	}
+}
+
+func TestFindAndParseGoSum(t *testing.T) {
+	tempDir := t.TempDir()
+	subDir := filepath.Join(tempDir, "internal")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte("module example.com/test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := FindAndParseGoSum(subDir); !errors.Is(err, utils.ErrGoSumNotFound) {
+		t.Errorf("Expected ErrGoSumNotFound, got %v", err)
+	}
+
+	goSumPath := filepath.Join(tempDir, "go.sum")
+	if err := os.WriteFile(goSumPath, []byte(testGoSum+"bad line\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := FindAndParseGoSum(subDir)
+	var parseErr *parser.ParseError
+	if !errors.As(err, &parseErr) || parseErr.File != goSumPath || parseErr.Line != 7 {
+		t.Errorf("Expected positioned error in %s, got %v", goSumPath, err)
+	}
+
+	if err := os.WriteFile(goSumPath, []byte(testGoSum), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := FindAndParseGoSum(subDir)
+	if err != nil || len(f.Entries) != 5 {
+		t.Errorf("FindAndParseGoSum = %v, %v", f, err)
+	}
+}
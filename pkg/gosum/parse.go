@@ -0,0 +1,169 @@
+package gosum
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/scagogogo/go-mod-parser/pkg/parser"
+	"github.com/scagogogo/go-mod-parser/pkg/semver"
+	"github.com/scagogogo/go-mod-parser/pkg/utils"
+)
+
+// Options 控制go.sum文件的解析行为
+type Options struct {
+	// FileName 文件名，用于在ParseError中标识出错的文件
+	FileName string
+
+	// Recover 为true时跳过格式错误和重复的行并继续解析，
+	// 返回解析到的记录以及包含所有诊断信息的ErrorList
+	Recover bool
+}
+
+// ParseWithOptions 按照指定选项从io.Reader解析go.sum文件
+// 错误以定位到具体行列的*parser.ParseError返回；默认遇到第一个错误即返回，
+// 开启Recover后总是返回解析到的记录，存在错误时同时返回parser.ErrorList
+func ParseWithOptions(r io.Reader, opts Options) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f, errs := parse(data, opts.FileName)
+	if len(errs) > 0 {
+		if !opts.Recover {
+			return nil, errs[0]
+		}
+		return f, errs
+	}
+	return f, nil
+}
+
+// ParseFromReader 从io.Reader解析go.sum文件
+func ParseFromReader(r io.Reader) (*File, error) {
+	return ParseWithOptions(r, Options{})
+}
+
+// ParseFromString 从字符串解析go.sum文件
+func ParseFromString(s string) (*File, error) {
+	return ParseFromReader(strings.NewReader(s))
+}
+
+// ParseFromFile 从文件解析go.sum文件
+func ParseFromFile(path string) (*File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseWithOptions(file, Options{FileName: path})
+}
+
+// FindAndParseGoSum 在指定目录及其父目录中查找go.mod文件，并解析与其位于同一目录的go.sum文件
+// 没有找到go.mod时返回utils.ErrGoModNotFound，模块没有go.sum时返回utils.ErrGoSumNotFound
+func FindAndParseGoSum(dir string) (*File, error) {
+	path, err := utils.FindGoSumFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFromFile(path)
+}
+
+// parse 逐行解析go.sum，跳过空行，收集所有格式错误和重复的行
+func parse(data []byte, fileName string) (*File, parser.ErrorList) {
+	f := &File{Entries: make([]*Entry, 0)}
+	var errs parser.ErrorList
+	seen := make(map[string]int)
+
+	for i, line := range strings.Split(string(bytes.TrimSuffix(data, []byte("\n"))), "\n") {
+		lineNum := i + 1
+		line = strings.TrimSuffix(line, "\r")
+		fields, columns := splitLine(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		entry, field, err := parseEntry(fields)
+		if err != nil {
+			if field < 0 {
+				errs = append(errs, lineError(fileName, lineNum, columns[0], strings.TrimSpace(line), err))
+			} else {
+				errs = append(errs, lineError(fileName, lineNum, columns[field], fields[field], err))
+			}
+			continue
+		}
+		entry.Line = lineNum
+
+		key := entry.String()
+		if first, ok := seen[key]; ok {
+			err := fmt.Errorf("%w (first seen on line %d)", ErrDuplicateLine, first)
+			errs = append(errs, lineError(fileName, lineNum, columns[0], strings.TrimSpace(line), err))
+			continue
+		}
+		seen[key] = lineNum
+		f.Entries = append(f.Entries, entry)
+	}
+	return f, errs
+}
+
+// parseEntry 由一行的字段得到记录，出错时返回出错字段的下标，整行出错时为-1
+func parseEntry(fields []string) (*Entry, int, error) {
+	if len(fields) != 3 {
+		return nil, -1, fmt.Errorf("%w: expected path, version and hash, got %d fields", ErrMalformedLine, len(fields))
+	}
+
+	entry := &Entry{Path: fields[0], Version: fields[1]}
+	if strings.HasSuffix(entry.Version, GoModSuffix) {
+		entry.Version = strings.TrimSuffix(entry.Version, GoModSuffix)
+		entry.GoMod = true
+	}
+	if !semver.IsValid(entry.Version) {
+		return nil, 1, ErrInvalidVersion
+	}
+
+	algorithm, digest, ok := strings.Cut(fields[2], ":")
+	if !ok || algorithm == "" || digest == "" {
+		return nil, 2, fmt.Errorf("%w: expected algorithm:digest", ErrInvalidHash)
+	}
+	if algorithm == "h1" {
+		if sum, err := base64.StdEncoding.DecodeString(digest); err != nil || len(sum) != 32 {
+			return nil, 2, fmt.Errorf("%w: h1 digest must be a base64-encoded SHA-256 sum", ErrInvalidHash)
+		}
+	}
+	entry.Algorithm, entry.Digest = algorithm, digest
+	return entry, 0, nil
+}
+
+// splitLine 按空白字符拆分一行，同时返回每个字段的列号（按字节计算，从1开始）
+func splitLine(line string) (fields []string, columns []int) {
+	start := -1
+	for i := 0; i <= len(line); i++ {
+		if i < len(line) && line[i] != ' ' && line[i] != '\t' {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			fields = append(fields, line[start:i])
+			columns = append(columns, start+1)
+			start = -1
+		}
+	}
+	return fields, columns
+}
+
+// lineError 构造定位到某一行某一列的ParseError
+func lineError(fileName string, line, column int, text string, err error) *parser.ParseError {
+	return &parser.ParseError{
+		File:   fileName,
+		Line:   line,
+		Column: column,
+		Text:   text,
+		Err:    err,
+	}
+}
@@ -0,0 +1,108 @@
+package gosum
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/scagogogo/go-mod-parser/pkg/dirhash"
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/scagogogo/go-mod-parser/pkg/parser"
+)
+
+var (
+	// ErrNotLocalReplace 表示replace声明的替换目标不是本地目录
+	ErrNotLocalReplace = errors.New("replacement is not a local directory")
+	// ErrUnknownVersion 表示无法确定本地替换对应的模块版本
+	ErrUnknownVersion = errors.New("cannot determine module version")
+)
+
+// HashCheck 表示一种校验和的计算结果与go.sum中记录的比较
+type HashCheck struct {
+	// Computed 计算得到的校验和
+	Computed string
+
+	// Recorded go.sum中记录的校验和，没有记录时为空
+	Recorded []string
+}
+
+// Missing 检查go.sum中是否没有记录该校验和
+func (c *HashCheck) Missing() bool {
+	return len(c.Recorded) == 0
+}
+
+// OK 检查计算得到的校验和是否与go.sum中的记录一致，没有记录时返回false
+func (c *HashCheck) OK() bool {
+	for _, h := range c.Recorded {
+		if h == c.Computed {
+			return true
+		}
+	}
+	return false
+}
+
+// Verification 表示本地模块目录与go.sum中记录的校验和的比较结果
+type Verification struct {
+	// Path 模块路径
+	Path string
+
+	// Version 模块版本
+	Version string
+
+	// Dir 计算校验和的本地目录
+	Dir string
+
+	// Content 模块完整内容的校验和
+	Content HashCheck
+
+	// GoMod 模块go.mod文件的校验和
+	GoMod HashCheck
+}
+
+// OK 检查完整内容校验和与go.mod校验和是否都与go.sum中的记录一致
+func (v *Verification) OK() bool {
+	return v.Content.OK() && v.GoMod.OK()
+}
+
+// VerifyDir 计算本地模块目录的完整内容校验和与go.mod校验和，并与go.sum中 path version 的记录比较
+// 目录中的文件按go命令打包模块的规则过滤，见dirhash.HashModuleDir
+func (f *File) VerifyDir(path, version, dir string) (*Verification, error) {
+	content, err := dirhash.HashModuleDir(dir, path, version)
+	if err != nil {
+		return nil, err
+	}
+	goMod, err := dirhash.HashGoModFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Verification{
+		Path:    path,
+		Version: version,
+		Dir:     dir,
+		Content: HashCheck{Computed: content, Recorded: f.Hashes(path, version, false)},
+		GoMod:   HashCheck{Computed: goMod, Recorded: f.Hashes(path, version, true)},
+	}, nil
+}
+
+// VerifyLocalReplace 检查本地目录替换的目标是否与go.sum中被替换模块版本的记录一致，
+// 例如确认替换为本地副本的依赖没有被修改
+// 模块版本优先使用replace声明左侧的版本，否则使用require声明的版本
+func VerifyLocalReplace(mod *module.Module, sum *File, rep *module.Replace) (*Verification, error) {
+	status := parser.CheckLocalReplace(rep)
+	if status == nil {
+		return nil, fmt.Errorf("%w: %s => %s", ErrNotLocalReplace, rep.Old.Path, rep.New.Path)
+	}
+
+	version := rep.Old.Version
+	if version == "" {
+		if req := parser.GetRequire(mod, rep.Old.Path); req != nil {
+			version = req.Version
+		}
+	}
+	if version == "" {
+		return nil, fmt.Errorf("%w: %s is not required", ErrUnknownVersion, rep.Old.Path)
+	}
+
+	return sum.VerifyDir(rep.Old.Path, version, status.Dir)
+}
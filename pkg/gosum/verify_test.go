@@ -0,0 +1,108 @@
+package gosum
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/parser"
+)
+
+// 与dirhash测试相同的模块内容及其校验和
+const (
+	localGoMod       = "module example.com/m\n\ngo 1.21\n"
+	localModuleHash  = "h1:fMmDKxylWHrLDMBXPo7zB62VLWneCU9/dHwc0CJrWDU="
+	localGoModHash   = "h1:ONeDgCa5UF/jJRjGzpOKmUiezgFEk4IPFZ96frvroW0="
+	unrelatedSumHash = "h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c="
+)
+
+func TestVerifyLocalReplace(t *testing.T) {
+	root := t.TempDir()
+	local := filepath.Join(root, "local")
+	if err := os.MkdirAll(local, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(local, "go.mod"), []byte(localGoMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(local, "m.go"), []byte("package m\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := filepath.Join(root, "app")
+	if err := os.MkdirAll(app, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	goModPath := filepath.Join(app, "go.mod")
+	err := os.WriteFile(goModPath, []byte(`module example.com/app
+
+go 1.21
+
+require (
+	example.com/m v1.0.0
+	example.com/other v1.2.0
+)
+
+replace example.com/m => ../local
+
+replace example.com/other v1.2.0 => ../local
+
+replace example.com/unrequired => ../local
+
+replace example.com/fork => example.com/forked v1.0.0
+
+replace example.com/missing => ../missing
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mod, err := parser.ParseGoModFile(goModPath)
+	if err != nil {
+		t.Fatalf("Failed to parse go.mod: %v", err)
+	}
+
+	sum, err := ParseFromString("example.com/m v1.0.0 " + localModuleHash + "\n" +
+		"example.com/m v1.0.0/go.mod " + localGoModHash + "\n" +
+		"example.com/other v1.2.0 " + unrelatedSumHash + "\n")
+	if err != nil {
+		t.Fatalf("Failed to parse go.sum: %v", err)
+	}
+
+	v, err := VerifyLocalReplace(mod, sum, mod.Replaces[0])
+	if err != nil {
+		t.Fatalf("VerifyLocalReplace() error = %v", err)
+	}
+	if v.Path != "example.com/m" || v.Version != "v1.0.0" || v.Dir != local {
+		t.Errorf("VerifyLocalReplace() = %s@%s in %s", v.Path, v.Version, v.Dir)
+	}
+	if !v.OK() || v.Content.Computed != localModuleHash || v.GoMod.Computed != localGoModHash {
+		t.Errorf("VerifyLocalReplace() content = %+v, go.mod = %+v, want match", v.Content, v.GoMod)
+	}
+
+	// 版本来自replace声明左侧；完整内容校验和不一致，go.mod校验和没有记录
+	v, err = VerifyLocalReplace(mod, sum, mod.Replaces[1])
+	if err != nil {
+		t.Fatalf("VerifyLocalReplace() error = %v", err)
+	}
+	if v.Version != "v1.2.0" || v.OK() {
+		t.Errorf("VerifyLocalReplace() version = %s, OK = %v", v.Version, v.OK())
+	}
+	if v.Content.OK() || v.Content.Missing() {
+		t.Errorf("Content = %+v, want mismatch", v.Content)
+	}
+	if !v.GoMod.Missing() || v.GoMod.Computed != localGoModHash {
+		t.Errorf("GoMod = %+v, want missing", v.GoMod)
+	}
+
+	if _, err := VerifyLocalReplace(mod, sum, mod.Replaces[2]); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("VerifyLocalReplace() unrequired error = %v, want ErrUnknownVersion", err)
+	}
+	if _, err := VerifyLocalReplace(mod, sum, mod.Replaces[3]); !errors.Is(err, ErrNotLocalReplace) {
+		t.Errorf("VerifyLocalReplace() module replace error = %v, want ErrNotLocalReplace", err)
+	}
+	mod.Replaces[4].Old.Version = "v1.0.0"
+	if _, err := VerifyLocalReplace(mod, sum, mod.Replaces[4]); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("VerifyLocalReplace() missing dir error = %v, want not exist", err)
+	}
+}
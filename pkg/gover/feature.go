@@ -0,0 +1,55 @@
+package gover
+
+// Feature 表示取决于go.mod中go版本的模块行为或指令
+type Feature int
+
+const (
+	// FeatureLazyLoading 模块图修剪和懒加载（go 1.17），go.mod需要列出所有间接依赖
+	FeatureLazyLoading Feature = iota
+
+	// FeatureToolchain toolchain指令（go 1.21），go版本同时成为最低要求的工具链版本
+	FeatureToolchain
+
+	// FeatureGodebug godebug指令（go 1.23）
+	FeatureGodebug
+
+	// FeatureTool tool指令（go 1.24）
+	FeatureTool
+)
+
+// features 记录每个特性的名称和最低go版本
+var features = map[Feature]struct {
+	name       string
+	minVersion string
+}{
+	FeatureLazyLoading: {"lazy loading", "1.17"},
+	FeatureToolchain:   {"toolchain directive", "1.21"},
+	FeatureGodebug:     {"godebug directive", "1.23"},
+	FeatureTool:        {"tool directive", "1.24"},
+}
+
+// String 返回特性的名称
+func (f Feature) String() string {
+	if info, ok := features[f]; ok {
+		return info.name
+	}
+	return "unknown"
+}
+
+// MinVersion 返回支持该特性的最低go版本，未知的特性返回空字符串
+func (f Feature) MinVersion() string {
+	return features[f].minVersion
+}
+
+// Supports 检查go声明的版本是否支持某个特性，按语言版本比较，例如 1.21rc1 支持toolchain指令
+// 版本为空时使用DefaultGoModVersion；版本不合法或特性未知时返回false
+func Supports(goVersion string, f Feature) bool {
+	if goVersion == "" {
+		goVersion = DefaultGoModVersion
+	}
+	minVersion := f.MinVersion()
+	if !IsValid(goVersion) || minVersion == "" {
+		return false
+	}
+	return Compare(Lang(goVersion), minVersion) >= 0
+}
@@ -0,0 +1,224 @@
+// Package gover 实现go.mod中go声明和toolchain声明使用的Go版本
+//
+// Go版本不是语义化版本：没有 v 前缀，语言版本可以省略修订号，预发布版本直接跟在版本号之后，
+// 例如 1.21、1.21.0、1.21rc1、1.21.0rc1、1.21.3。版本之间的顺序为
+//
+//	1.20 = 1.20.0 < 1.21 < 1.21rc1 < 1.21rc2 < 1.21.0rc1 < 1.21.0 < 1.21.1
+//
+// 从Go 1.21开始，1.N 表示语言版本，第一个正式发布的版本为 1.N.0；
+// 更早的版本中 1.N 就是第一个正式发布的版本，与 1.N.0 相同。
+package gover
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidVersion 表示版本号不是合法的Go版本
+	ErrInvalidVersion = errors.New("invalid go version")
+)
+
+// DefaultGoModVersion go.mod中没有go声明时go命令假定的语言版本
+const DefaultGoModVersion = "1.16"
+
+// Version 表示解析后的Go版本
+type Version struct {
+	// Major 主版本号，例如 1.21.3 中的 "1"
+	Major string
+
+	// Minor 次版本号，例如 1.21.3 中的 "21"
+	Minor string
+
+	// Patch 修订号，例如 1.21.3 中的 "3"，语言版本和 1.21rc1 这样的预发布版本中为空
+	Patch string
+
+	// Kind 预发布类型，例如 1.21rc1 和 1.21.0rc1 中的 "rc"，正式版本中为空
+	Kind string
+
+	// Pre 预发布序号，例如 1.21rc1 中的 "1"
+	Pre string
+}
+
+// String 返回版本的文本形式
+func (v Version) String() string {
+	s := v.Major + "." + v.Minor
+	if v.Patch != "" {
+		s += "." + v.Patch
+	}
+	return s + v.Kind + v.Pre
+}
+
+// Parse 解析Go版本，格式为 主版本.次版本[.修订号][预发布类型+序号]，
+// 例如 1.21、1.21.0、1.21rc1、1.21.0rc1，与go.mod中go声明接受的格式相同；数字不允许前导0
+func Parse(v string) (Version, error) {
+	p, ok := parse(v)
+	if !ok {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, v)
+	}
+	return p, nil
+}
+
+// IsValid 检查版本号是否为合法的Go版本
+func IsValid(v string) bool {
+	_, ok := parse(v)
+	return ok
+}
+
+// Lang 返回版本对应的语言版本，例如 1.21.3 和 1.21rc1 都返回 "1.21"；不合法的版本返回空字符串
+func Lang(v string) string {
+	p, ok := parse(v)
+	if !ok {
+		return ""
+	}
+	return p.Major + "." + p.Minor
+}
+
+// IsLang 检查版本号是否为语言版本，即只有主版本号和次版本号，例如 1.21
+func IsLang(v string) bool {
+	p, ok := parse(v)
+	return ok && p.Patch == "" && p.Kind == ""
+}
+
+// Compare 比较两个Go版本，x小于、等于、大于y时分别返回-1、0、1
+// 不合法的版本小于所有合法的版本，相互之间视为相等
+func Compare(x, y string) int {
+	px, okX := parse(x)
+	py, okY := parse(y)
+	switch {
+	case !okX && !okY:
+		return 0
+	case !okX:
+		return -1
+	case !okY:
+		return 1
+	}
+
+	if c := compareInt(px.Major, py.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(px.Minor, py.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(normalizePatch(px), normalizePatch(py)); c != 0 {
+		return c
+	}
+	// 带修订号时预发布版本低于正式版本，例如 1.21.0rc1 < 1.21.0；
+	// 语言版本则低于它的预发布版本，例如 1.21 < 1.21rc1
+	if normalizePatch(px) != "" && (px.Kind == "") != (py.Kind == "") {
+		if px.Kind == "" {
+			return 1
+		}
+		return -1
+	}
+	if c := strings.Compare(px.Kind, py.Kind); c != 0 {
+		return c
+	}
+	return compareInt(px.Pre, py.Pre)
+}
+
+// Max 返回两个版本中较大的一个，相等时返回x
+func Max(x, y string) string {
+	if Compare(x, y) < 0 {
+		return y
+	}
+	return x
+}
+
+// Less 检查x是否低于y
+func Less(x, y string) bool {
+	return Compare(x, y) < 0
+}
+
+// FromToolchain 返回工具链名称对应的Go版本，忽略自定义后缀，
+// 例如 go1.21.5 和 go1.22.0-custom 分别返回 1.21.5 和 1.22.0；不是合法的工具链名称时返回空字符串
+func FromToolchain(name string) string {
+	if !strings.HasPrefix(name, "go") {
+		return ""
+	}
+	v := name[len("go"):]
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	if !IsValid(v) {
+		return ""
+	}
+	return v
+}
+
+// normalizePatch 返回用于比较的修订号
+// 1.21之前的 1.N 与 1.N.0 相同；从1.21开始语言版本 1.N 低于 1.N.0，以空修订号表示
+func normalizePatch(v Version) string {
+	if v.Patch == "" && v.Kind == "" && v.Major == "1" && compareInt(v.Minor, "21") < 0 {
+		return "0"
+	}
+	return v.Patch
+}
+
+// parse 解析Go版本，格式不合法时返回false
+func parse(v string) (p Version, ok bool) {
+	if p.Major, v, ok = cutInt(v); !ok {
+		return
+	}
+	if v == "" || v[0] != '.' {
+		return Version{}, false
+	}
+	if p.Minor, v, ok = cutInt(v[1:]); !ok {
+		return Version{}, false
+	}
+	if v == "" {
+		return p, true
+	}
+
+	if v[0] == '.' {
+		if p.Patch, v, ok = cutInt(v[1:]); !ok {
+			return Version{}, false
+		}
+		if v == "" {
+			return p, true
+		}
+	}
+
+	i := 0
+	for i < len(v) && 'a' <= v[i] && v[i] <= 'z' {
+		i++
+	}
+	if i == 0 {
+		return Version{}, false
+	}
+	p.Kind, v = v[:i], v[i:]
+	if p.Pre, v, ok = cutInt(v); !ok || v != "" {
+		return Version{}, false
+	}
+	return p, true
+}
+
+// cutInt 取出开头的十进制数字，除0本身以外不允许前导0
+func cutInt(v string) (n, rest string, ok bool) {
+	i := 0
+	for i < len(v) && '0' <= v[i] && v[i] <= '9' {
+		i++
+	}
+	if i == 0 || v[0] == '0' && i != 1 {
+		return "", "", false
+	}
+	return v[:i], v[i:], true
+}
+
+// compareInt 比较两个不带前导0的十进制数字字符串，空字符串小于所有数字
+func compareInt(x, y string) int {
+	if x == y {
+		return 0
+	}
+	if len(x) < len(y) {
+		return -1
+	}
+	if len(x) > len(y) {
+		return 1
+	}
+	if x < y {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,173 @@
+package gover
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected Version
+		valid    bool
+	}{
+		{"1.21", Version{Major: "1", Minor: "21"}, true},
+		{"1.21.0", Version{Major: "1", Minor: "21", Patch: "0"}, true},
+		{"1.21.13", Version{Major: "1", Minor: "21", Patch: "13"}, true},
+		{"1.21rc1", Version{Major: "1", Minor: "21", Kind: "rc", Pre: "1"}, true},
+		{"1.18beta2", Version{Major: "1", Minor: "18", Kind: "beta", Pre: "2"}, true},
+		{"2.0", Version{Major: "2", Minor: "0"}, true},
+		{"", Version{}, false},
+		{"1", Version{}, false},
+		{"1.", Version{}, false},
+		{"v1.21", Version{}, false},
+		{"go1.21", Version{}, false},
+		{"1.021", Version{}, false},
+		{"1.21.", Version{}, false},
+		{"1.21.0rc1", Version{Major: "1", Minor: "21", Patch: "0", Kind: "rc", Pre: "1"}, true},
+		{"1.20.1beta1", Version{Major: "1", Minor: "20", Patch: "1", Kind: "beta", Pre: "1"}, true},
+		{"1.21.0rc", Version{}, false},
+		{"1.21.0.rc1", Version{}, false},
+		{"1.21rc", Version{}, false},
+		{"1.21RC1", Version{}, false},
+		{"1.21.x", Version{}, false},
+		{"1.21 ", Version{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := Parse(tt.version)
+			if !tt.valid {
+				if !errors.Is(err, ErrInvalidVersion) || IsValid(tt.version) {
+					t.Errorf("Parse(%q) = %+v, %v, want ErrInvalidVersion", tt.version, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.expected || !IsValid(tt.version) {
+				t.Errorf("Parse(%q) = %+v, %v, want %+v", tt.version, got, err, tt.expected)
+			}
+			if got.String() != tt.version {
+				t.Errorf("Parse(%q).String() = %q", tt.version, got.String())
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// 从小到大排列
+	ordered := []string{
+		"1.19beta1",
+		"1.19rc1",
+		"1.19",
+		"1.19.1rc1",
+		"1.19.1",
+		"1.20.13",
+		"1.21",
+		"1.21beta1",
+		"1.21rc1",
+		"1.21rc2",
+		"1.21.0rc1",
+		"1.21.0rc2",
+		"1.21.0",
+		"1.21.1",
+		"1.21.10",
+		"1.22",
+		"2.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := Compare(ordered[i], ordered[j]); got != want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+
+	if Compare("1.20", "1.20.0") != 0 {
+		t.Error("Expected 1.20 to equal 1.20.0")
+	}
+	if Compare("bad", "1.0") != -1 || Compare("1.0", "bad") != 1 || Compare("bad", "worse") != 0 {
+		t.Error("Expected invalid versions to sort first")
+	}
+	if Max("1.21rc1", "1.21.0") != "1.21.0" || !Less("1.21", "1.21.0") {
+		t.Error("Unexpected Max or Less result")
+	}
+}
+
+func TestLang(t *testing.T) {
+	tests := []struct {
+		version string
+		lang    string
+		isLang  bool
+	}{
+		{"1.21", "1.21", true},
+		{"1.21.3", "1.21", false},
+		{"1.21rc1", "1.21", false},
+		{"1.21.0rc1", "1.21", false},
+		{"bad", "", false},
+	}
+	for _, tt := range tests {
+		if got := Lang(tt.version); got != tt.lang {
+			t.Errorf("Lang(%q) = %q, want %q", tt.version, got, tt.lang)
+		}
+		if got := IsLang(tt.version); got != tt.isLang {
+			t.Errorf("IsLang(%q) = %v, want %v", tt.version, got, tt.isLang)
+		}
+	}
+}
+
+func TestFromToolchain(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"go1.21.5", "1.21.5"},
+		{"go1.22.0-custom", "1.22.0"},
+		{"go1.21rc1", "1.21rc1"},
+		{"go1.21.0+auto", "1.21.0"},
+		{"default", ""},
+		{"1.21.5", ""},
+		{"gofoo", ""},
+	}
+	for _, tt := range tests {
+		if got := FromToolchain(tt.name); got != tt.expected {
+			t.Errorf("FromToolchain(%q) = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestSupports(t *testing.T) {
+	tests := []struct {
+		version  string
+		feature  Feature
+		expected bool
+	}{
+		{"1.16", FeatureLazyLoading, false},
+		{"1.17", FeatureLazyLoading, true},
+		{"1.17.13", FeatureLazyLoading, true},
+		{"", FeatureLazyLoading, false},
+		{"1.20", FeatureToolchain, false},
+		{"1.21rc1", FeatureToolchain, true},
+		{"1.21", FeatureToolchain, true},
+		{"1.22.5", FeatureGodebug, false},
+		{"1.23.0", FeatureGodebug, true},
+		{"1.23.4", FeatureTool, false},
+		{"1.24", FeatureTool, true},
+		{"bad", FeatureLazyLoading, false},
+		{"1.24", Feature(99), false},
+	}
+	for _, tt := range tests {
+		if got := Supports(tt.version, tt.feature); got != tt.expected {
+			t.Errorf("Supports(%q, %s) = %v, want %v", tt.version, tt.feature, got, tt.expected)
+		}
+	}
+
+	if FeatureTool.String() != "tool directive" || FeatureTool.MinVersion() != "1.24" || Feature(99).String() != "unknown" {
+		t.Error("Unexpected feature name or minimum version")
+	}
+}
@@ -0,0 +1,166 @@
+// Package lint 对解析得到的go.mod内容进行语义检查
+//
+// 每条规则都有唯一的ID和严重程度，可以通过 Options.Disable 单独关闭。
+// Lint 只检查模块信息；LintFile 还会根据语法树为每条诊断信息补充位置。
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/scagogogo/go-mod-parser/pkg/parser"
+)
+
+// Severity 表示诊断信息的严重程度
+type Severity int
+
+const (
+	// SeverityInfo 提示，不影响构建
+	SeverityInfo Severity = iota
+
+	// SeverityWarning 警告，声明可能无效或不符合预期
+	SeverityWarning
+
+	// SeverityError 错误，go命令会拒绝或忽略这样的声明
+	SeverityError
+)
+
+// String 返回严重程度的名称
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Rule 表示一条检查规则
+type Rule struct {
+	// ID 规则的唯一标识，例如 duplicate-require
+	ID string
+
+	// Severity 规则产生的诊断信息的严重程度
+	Severity Severity
+
+	// Description 规则的说明
+	Description string
+
+	// check 执行检查，返回发现的问题
+	check func(mod *module.Module, opts Options) []finding
+}
+
+// finding 表示规则发现的一个问题
+type finding struct {
+	// entry 问题所在的模块信息项，例如*module.Require，或指令关键字 "go"
+	entry any
+
+	// message 问题描述
+	message string
+}
+
+// Diagnostic 表示一条诊断信息
+type Diagnostic struct {
+	// Rule 产生诊断信息的规则ID
+	Rule string
+
+	// Severity 严重程度
+	Severity Severity
+
+	// Message 问题描述
+	Message string
+
+	// Entry 问题所在的模块信息项，例如*module.Require、*module.Replace，
+	// 或指令关键字 "module"、"go"、"toolchain"
+	Entry any
+
+	// Pos 问题所在语句的位置，仅由LintFile设置，否则为零值
+	Pos parser.Position
+}
+
+// String 返回诊断信息的文本形式，例如 "5:2: error: ... [duplicate-require]"
+func (d *Diagnostic) String() string {
+	if d.Pos.Line > 0 {
+		return fmt.Sprintf("%d:%d: %s: %s [%s]", d.Pos.Line, d.Pos.Column, d.Severity, d.Message, d.Rule)
+	}
+	return fmt.Sprintf("%s: %s [%s]", d.Severity, d.Message, d.Rule)
+}
+
+// Options 控制检查的执行
+type Options struct {
+	// Disable 要关闭的规则ID
+	Disable []string
+
+	// Versions 模块自身已发布的版本，用于检查retract声明；为空时跳过该检查
+	Versions []string
+}
+
+// disabled 检查规则是否被关闭
+func (o Options) disabled(id string) bool {
+	for _, d := range o.Disable {
+		if d == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Rules 返回所有内置规则，按执行顺序排列
+func Rules() []*Rule {
+	return []*Rule{
+		duplicateRequireRule,
+		excludedRequireRule,
+		unusedReplaceRule,
+		conflictingReplaceRule,
+		retractUnknownVersionRule,
+		goVersionTooOldRule,
+	}
+}
+
+// GetRule 根据ID获取内置规则，不存在时返回nil
+func GetRule(id string) *Rule {
+	for _, rule := range Rules() {
+		if rule.ID == id {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Lint 对模块信息执行所有未关闭的规则，按规则顺序返回诊断信息
+func Lint(mod *module.Module, opts Options) []*Diagnostic {
+	diagnostics := make([]*Diagnostic, 0)
+	for _, rule := range Rules() {
+		if opts.disabled(rule.ID) {
+			continue
+		}
+		for _, f := range rule.check(mod, opts) {
+			diagnostics = append(diagnostics, &Diagnostic{
+				Rule:     rule.ID,
+				Severity: rule.Severity,
+				Message:  f.message,
+				Entry:    f.entry,
+			})
+		}
+	}
+	return diagnostics
+}
+
+// LintFile 对解析得到的文件执行检查，诊断信息定位到对应的语句并按位置排序
+func LintFile(f *parser.File, opts Options) []*Diagnostic {
+	diagnostics := Lint(f.Module, opts)
+	for _, d := range diagnostics {
+		if line := f.Line(d.Entry); line != nil {
+			d.Pos = line.Start
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Pos.Byte < diagnostics[j].Pos.Byte
+	})
+	return diagnostics
+}
@@ -0,0 +1,149 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/scagogogo/go-mod-parser/pkg/parser"
+)
+
+const testGoMod = `module github.com/example/module
+
+go 1.20
+
+toolchain go1.21.5
+
+godebug panicnil=1
+
+require (
+	github.com/example/a v1.0.0
+	github.com/example/b v1.2.0
+	github.com/example/a v1.1.0
+)
+
+exclude github.com/example/b v1.2.0
+
+replace github.com/example/unused => ../unused
+
+replace (
+	github.com/example/a v1.0.0 => github.com/fork/a v1.0.0
+	github.com/example/a v1.0.0 => github.com/fork/a v1.0.0
+	github.com/example/a v1.0.0 => ../a
+)
+
+retract (
+	v0.9.0
+	[v2.0.0, v2.1.0]
+)
+`
+
+func TestLintFile(t *testing.T) {
+	f, err := parser.ParseSyntaxFromString(testGoMod)
+	if err != nil {
+		t.Fatalf("Failed to parse go.mod: %v", err)
+	}
+
+	diagnostics := LintFile(f, Options{Versions: []string{"v0.9.0", "v1.0.0"}})
+
+	expected := []struct {
+		rule     string
+		severity Severity
+		line     int
+		message  string
+	}{
+		{RuleGoVersionTooOld, SeverityError, 5, "toolchain directive requires go >= 1.21, but go version is 1.20"},
+		{RuleGoVersionTooOld, SeverityError, 7, "godebug directive requires go >= 1.23"},
+		{RuleExcludedRequire, SeverityWarning, 11, "github.com/example/b v1.2.0: required version is excluded"},
+		{RuleDuplicateRequire, SeverityError, 12, "github.com/example/a v1.1.0: module is already required at v1.0.0"},
+		{RuleUnusedReplace, SeverityWarning, 17, "replace github.com/example/unused: module is not required"},
+		{RuleConflictingReplace, SeverityError, 22, "conflicting replacements github.com/fork/a@v1.0.0 and ../a"},
+		{RuleRetractUnknownVersion, SeverityWarning, 27, "retract [v2.0.0, v2.1.0]: no published version"},
+	}
+	if len(diagnostics) != len(expected) {
+		for _, d := range diagnostics {
+			t.Log(d)
+		}
+		t.Fatalf("Expected %d diagnostics, got %d", len(expected), len(diagnostics))
+	}
+	for i, want := range expected {
+		d := diagnostics[i]
+		if d.Rule != want.rule || d.Severity != want.severity || d.Pos.Line != want.line || !strings.Contains(d.Message, want.message) {
+			t.Errorf("Diagnostic %d = %s, want %s at line %d containing %q", i, d, want.rule, want.line, want.message)
+		}
+	}
+
+	if s := diagnostics[3].String(); s != "12:2: error: github.com/example/a v1.1.0: module is already required at v1.0.0 [duplicate-require]" {
+		t.Errorf("Unexpected diagnostic string: %s", s)
+	}
+}
+
+func TestLint_Disable(t *testing.T) {
+	f, err := parser.ParseSyntaxFromString(testGoMod)
+	if err != nil {
+		t.Fatalf("Failed to parse go.mod: %v", err)
+	}
+
+	diagnostics := Lint(f.Module, Options{Disable: []string{RuleGoVersionTooOld, RuleUnusedReplace, RuleDuplicateRequire}})
+	rules := make(map[string]int)
+	for _, d := range diagnostics {
+		rules[d.Rule]++
+		if d.Pos.Line != 0 {
+			t.Errorf("Expected Lint not to set positions, got %s", d)
+		}
+	}
+
+	// 没有提供已发布版本时不检查retract
+	if len(rules) != 2 || rules[RuleExcludedRequire] != 1 || rules[RuleConflictingReplace] != 1 {
+		t.Errorf("Unexpected rules reported: %v", rules)
+	}
+}
+
+func TestLint_GoVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected int
+	}{
+		{"new enough", "module example.com/m\n\ngo 1.24\n\ntoolchain go1.24.1\n\ngodebug panicnil=1\n\ntool example.com/m/cmd\n", 0},
+		{"release candidate", "module example.com/m\n\ngo 1.21rc1\n\ntoolchain go1.21.5\n", 0},
+		{"tool needs 1.24", "module example.com/m\n\ngo 1.23.4\n\ntool example.com/m/cmd\n", 1},
+		{"missing go directive", "module example.com/m\n\ntoolchain go1.21.0\n", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mod, err := parser.ParseFromString(tt.content)
+			if err != nil {
+				t.Fatalf("Failed to parse go.mod: %v", err)
+			}
+			if got := Lint(mod, Options{}); len(got) != tt.expected {
+				t.Errorf("Expected %d diagnostics, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	// 无法解析的go版本由解析器报告，这里不重复检查
+	if got := Lint(&module.Module{GoVersion: "latest", Toolchain: "go1.21.0"}, Options{}); len(got) != 0 {
+		t.Errorf("Expected no diagnostics for an invalid go version, got %v", got)
+	}
+}
+
+func TestRules(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, rule := range Rules() {
+		if rule.ID == "" || rule.Description == "" || seen[rule.ID] {
+			t.Errorf("Invalid or duplicate rule: %+v", rule)
+		}
+		seen[rule.ID] = true
+		if GetRule(rule.ID) == nil {
+			t.Errorf("GetRule(%q) returned nil", rule.ID)
+		}
+	}
+	if GetRule("no-such-rule") != nil {
+		t.Error("Expected nil for unknown rule")
+	}
+	if SeverityWarning.String() != "warning" || Severity(9).String() != "unknown" {
+		t.Error("Unexpected severity names")
+	}
+}
@@ -0,0 +1,213 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/scagogogo/go-mod-parser/pkg/gover"
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/scagogogo/go-mod-parser/pkg/semver"
+)
+
+// 内置规则的ID
+const (
+	// RuleDuplicateRequire 同一模块路径被require多次
+	RuleDuplicateRequire = "duplicate-require"
+
+	// RuleExcludedRequire require的版本被exclude排除
+	RuleExcludedRequire = "excluded-require"
+
+	// RuleUnusedReplace replace的模块从未被require
+	RuleUnusedReplace = "unused-replace"
+
+	// RuleConflictingReplace 同一模块（及版本）有多个不同的replace目标
+	RuleConflictingReplace = "conflicting-replace"
+
+	// RuleRetractUnknownVersion retract声明不包含任何已发布的版本
+	RuleRetractUnknownVersion = "retract-unknown-version"
+
+	// RuleGoVersionTooOld go声明的版本低于所用指令要求的版本
+	RuleGoVersionTooOld = "go-version-too-old"
+)
+
+var duplicateRequireRule = &Rule{
+	ID:          RuleDuplicateRequire,
+	Severity:    SeverityError,
+	Description: "a module path is required more than once",
+	check: func(mod *module.Module, _ Options) []finding {
+		var findings []finding
+		first := make(map[string]*module.Require)
+		for _, req := range mod.Requires {
+			prev, ok := first[req.Path]
+			if !ok {
+				first[req.Path] = req
+				continue
+			}
+			findings = append(findings, finding{
+				entry:   req,
+				message: fmt.Sprintf("%s %s: module is already required at %s", req.Path, req.Version, prev.Version),
+			})
+		}
+		return findings
+	},
+}
+
+var excludedRequireRule = &Rule{
+	ID:          RuleExcludedRequire,
+	Severity:    SeverityWarning,
+	Description: "a required version is excluded by an exclude directive",
+	check: func(mod *module.Module, _ Options) []finding {
+		var findings []finding
+		for _, req := range mod.Requires {
+			for _, exc := range mod.Excludes {
+				if exc.Path == req.Path && sameVersion(exc.Version, req.Version) {
+					findings = append(findings, finding{
+						entry:   req,
+						message: fmt.Sprintf("%s %s: required version is excluded, the next higher version will be used instead", req.Path, req.Version),
+					})
+					break
+				}
+			}
+		}
+		return findings
+	},
+}
+
+var unusedReplaceRule = &Rule{
+	ID:          RuleUnusedReplace,
+	Severity:    SeverityWarning,
+	Description: "a replace directive refers to a module that is never required",
+	check: func(mod *module.Module, _ Options) []finding {
+		required := make(map[string]bool)
+		for _, req := range mod.Requires {
+			required[req.Path] = true
+		}
+
+		var findings []finding
+		for _, rep := range mod.Replaces {
+			if !required[rep.Old.Path] {
+				findings = append(findings, finding{
+					entry:   rep,
+					message: fmt.Sprintf("replace %s: module is not required, the replacement has no effect", rep.Old.Path),
+				})
+			}
+		}
+		return findings
+	},
+}
+
+var conflictingReplaceRule = &Rule{
+	ID:          RuleConflictingReplace,
+	Severity:    SeverityError,
+	Description: "the same module version is replaced more than once with different targets",
+	check: func(mod *module.Module, _ Options) []finding {
+		var findings []finding
+		first := make(map[string]*module.Replace)
+		for _, rep := range mod.Replaces {
+			key := rep.Old.Path + "@" + rep.Old.Version
+			prev, ok := first[key]
+			if !ok {
+				first[key] = rep
+				continue
+			}
+			if prev.New.Path != rep.New.Path || prev.New.Version != rep.New.Version {
+				findings = append(findings, finding{
+					entry: rep,
+					message: fmt.Sprintf("replace %s: conflicting replacements %s and %s",
+						moduleString(rep.Old.Path, rep.Old.Version),
+						moduleString(prev.New.Path, prev.New.Version),
+						moduleString(rep.New.Path, rep.New.Version)),
+				})
+			}
+		}
+		return findings
+	},
+}
+
+var retractUnknownVersionRule = &Rule{
+	ID:          RuleRetractUnknownVersion,
+	Severity:    SeverityWarning,
+	Description: "a retract directive does not cover any published version of the module",
+	check: func(mod *module.Module, opts Options) []finding {
+		if len(opts.Versions) == 0 {
+			return nil
+		}
+
+		var findings []finding
+		for _, ret := range mod.Retracts {
+			published := false
+			for _, v := range opts.Versions {
+				if ret.Contains(v) {
+					published = true
+					break
+				}
+			}
+			if !published {
+				findings = append(findings, finding{
+					entry:   ret,
+					message: fmt.Sprintf("retract %s: no published version of the module is retracted", retractString(ret)),
+				})
+			}
+		}
+		return findings
+	},
+}
+
+var goVersionTooOldRule = &Rule{
+	ID:          RuleGoVersionTooOld,
+	Severity:    SeverityError,
+	Description: "the go version is older than a directive in use requires",
+	check: func(mod *module.Module, _ Options) []finding {
+		if mod.GoVersion != "" && !gover.IsValid(mod.GoVersion) {
+			return nil
+		}
+		goVersion := mod.GoVersion
+		if goVersion == "" {
+			goVersion = gover.DefaultGoModVersion
+		}
+
+		var findings []finding
+		needs := func(entry any, feature gover.Feature) {
+			if !gover.Supports(goVersion, feature) {
+				findings = append(findings, finding{
+					entry:   entry,
+					message: fmt.Sprintf("%s requires go >= %s, but go version is %s", feature, feature.MinVersion(), goVersion),
+				})
+			}
+		}
+
+		if mod.Toolchain != "" {
+			needs("toolchain", gover.FeatureToolchain)
+		}
+		if len(mod.Godebugs) > 0 {
+			needs(mod.Godebugs[0], gover.FeatureGodebug)
+		}
+		if len(mod.Tools) > 0 {
+			needs(mod.Tools[0], gover.FeatureTool)
+		}
+		return findings
+	},
+}
+
+// sameVersion 检查两个版本号是否相同，合法的语义化版本按语义比较
+func sameVersion(v, w string) bool {
+	if semver.IsValid(v) && semver.IsValid(w) {
+		return semver.Compare(v, w) == 0
+	}
+	return v == w
+}
+
+// moduleString 返回 path 或 path@version 形式的模块描述
+func moduleString(path, version string) string {
+	if version == "" {
+		return path
+	}
+	return path + "@" + version
+}
+
+// retractString 返回retract声明的版本或版本范围
+func retractString(ret *module.Retract) string {
+	if ret.IsRange() {
+		return "[" + ret.VersionLow + ", " + ret.VersionHigh + "]"
+	}
+	return ret.Version
+}
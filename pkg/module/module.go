@@ -3,6 +3,9 @@ package module
 import (
 	"io"
 	"os"
+	"strings"
+
+	"github.com/scagogogo/go-mod-parser/pkg/semver"
 )
 
 // Module 表示一个go.mod文件的内容
@@ -10,12 +13,24 @@ type Module struct {
 	// Name 模块名称
 	Name string
 
+	// Deprecated 模块的弃用说明，来自module声明前或行尾的 "Deprecated:" 注释段落，未弃用时为空
+	Deprecated string
+
 	// GoVersion go版本
 	GoVersion string
 
+	// Toolchain 推荐使用的Go工具链，例如 go1.21.5，可能为空
+	Toolchain string
+
+	// Godebugs GODEBUG默认设置
+	Godebugs []*Godebug
+
 	// Requires 依赖项
 	Requires []*Require
 
+	// Tools 工具依赖
+	Tools []*Tool
+
 	// Replaces 替换规则
 	Replaces []*Replace
 
@@ -26,6 +41,15 @@ type Module struct {
 	Retracts []*Retract
 }
 
+// Godebug 表示一个godebug指令
+type Godebug struct {
+	// Key GODEBUG设置名称
+	Key string
+
+	// Value GODEBUG设置值
+	Value string
+}
+
 // Require 表示一个require指令
 type Require struct {
 	// Path 模块路径
@@ -38,6 +62,12 @@ type Require struct {
 	Indirect bool
 }
 
+// Tool 表示一个tool指令
+type Tool struct {
+	// Path 工具的包路径
+	Path string
+}
+
 // Replace 表示一个replace指令
 type Replace struct {
 	// Old 替换前的模块信息
@@ -47,6 +77,19 @@ type Replace struct {
 	New *ReplaceItem
 }
 
+// Kind 返回替换目标的类型
+func (r *Replace) Kind() ReplaceKind {
+	if r.New != nil && IsLocalPath(r.New.Path) {
+		return ReplaceKindLocal
+	}
+	return ReplaceKindModule
+}
+
+// IsLocal 检查替换目标是否为本地目录
+func (r *Replace) IsLocal() bool {
+	return r.Kind() == ReplaceKindLocal
+}
+
 // ReplaceItem 表示replace指令中的模块信息
 type ReplaceItem struct {
 	// Path 模块路径
@@ -54,6 +97,43 @@ type ReplaceItem struct {
 
 	// Version 模块版本，可能为空
 	Version string
+
+	// Dir 本地替换目录解析后的绝对路径
+	// 仅在通过文件解析且替换目标为相对目录或绝对目录时设置，其余情况为空
+	Dir string
+}
+
+// ReplaceKind 表示替换目标的类型
+type ReplaceKind int
+
+const (
+	// ReplaceKindModule 替换为另一个模块（可带版本）
+	ReplaceKindModule ReplaceKind = iota
+
+	// ReplaceKindLocal 替换为本地文件系统目录
+	ReplaceKindLocal
+)
+
+// String 返回替换类型的名称
+func (k ReplaceKind) String() string {
+	switch k {
+	case ReplaceKindModule:
+		return "module"
+	case ReplaceKindLocal:
+		return "local"
+	default:
+		return "unknown"
+	}
+}
+
+// IsLocalPath 检查替换目标路径是否为文件系统路径
+// 与go命令的规则一致：以 ./ 或 ../ 开头的相对路径，以及绝对路径
+func IsLocalPath(path string) bool {
+	return path == "." || path == ".." ||
+		strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") ||
+		strings.HasPrefix(path, ".\\") || strings.HasPrefix(path, "..\\") ||
+		strings.HasPrefix(path, "/") || strings.HasPrefix(path, "\\") ||
+		(len(path) >= 2 && path[1] == ':' && ('a' <= path[0]|0x20 && path[0]|0x20 <= 'z'))
 }
 
 // Exclude 表示一个exclude指令
@@ -78,6 +158,25 @@ type Retract struct {
 	Rationale string
 }
 
+// IsRange 检查撤回声明是否为版本范围
+func (r *Retract) IsRange() bool {
+	return r.VersionLow != "" || r.VersionHigh != ""
+}
+
+// Contains 检查撤回声明是否包含指定的版本，版本按照语义化版本比较，
+// 例如 v1.10.0 位于 [v1.9.0, v1.11.0] 范围内，v1.2 与 v1.2.0 视为同一版本。
+// 不合法的版本号只能与单个版本的声明逐字匹配
+func (r *Retract) Contains(version string) bool {
+	if !semver.IsValid(version) {
+		return r.Version != "" && r.Version == version
+	}
+	if !r.IsRange() {
+		return semver.IsValid(r.Version) && semver.Compare(r.Version, version) == 0
+	}
+	return semver.IsValid(r.VersionLow) && semver.IsValid(r.VersionHigh) &&
+		semver.Compare(r.VersionLow, version) <= 0 && semver.Compare(version, r.VersionHigh) <= 0
+}
+
 // OpenAndProcess 打开文件并使用处理函数处理
 func OpenAndProcess(path string, process func(io.Reader) (*Module, error)) (*Module, error) {
 	file, err := os.Open(path)
@@ -64,3 +64,84 @@ func TestOpenAndProcess_ErrorCase(t *testing.T) {
 		t.Error("Expected error when opening non-existent file, got nil")
 	}
 }
+
+func TestIsLocalPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"./fork", true},
+		{"../fork", true},
+		{".", true},
+		{"..", true},
+		{"/abs/fork", true},
+		{`..\fork`, true},
+		{`C:\src\fork`, true},
+		{"github.com/fork/x", false},
+		{"fork", false},
+		{".fork/x", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsLocalPath(tt.path); got != tt.expected {
+			t.Errorf("IsLocalPath(%q) = %v, want %v", tt.path, got, tt.expected)
+		}
+	}
+}
+
+func TestReplaceKind(t *testing.T) {
+	local := &Replace{
+		Old: &ReplaceItem{Path: "github.com/example/x"},
+		New: &ReplaceItem{Path: "../x"},
+	}
+	if local.Kind() != ReplaceKindLocal || !local.IsLocal() {
+		t.Errorf("Expected local replace, got %v", local.Kind())
+	}
+
+	remote := &Replace{
+		Old: &ReplaceItem{Path: "github.com/example/x"},
+		New: &ReplaceItem{Path: "github.com/fork/x", Version: "v1.0.0"},
+	}
+	if remote.Kind() != ReplaceKindModule || remote.IsLocal() {
+		t.Errorf("Expected module replace, got %v", remote.Kind())
+	}
+
+	if ReplaceKindLocal.String() != "local" || ReplaceKindModule.String() != "module" {
+		t.Errorf("Unexpected replace kind names: %s, %s", ReplaceKindLocal, ReplaceKindModule)
+	}
+}
+
+func TestRetractContains(t *testing.T) {
+	single := &Retract{Version: "v1.2.0"}
+	rng := &Retract{VersionLow: "v1.9.0", VersionHigh: "v1.11.0"}
+	literal := &Retract{Version: "bad-version"}
+
+	tests := []struct {
+		ret      *Retract
+		version  string
+		expected bool
+	}{
+		{single, "v1.2.0", true},
+		{single, "v1.2", true},
+		{single, "v1.2.1", false},
+		{rng, "v1.10.0", true},
+		{rng, "v1.9.0", true},
+		{rng, "v1.11.0", true},
+		{rng, "v1.11.0-rc.1", true},
+		{rng, "v1.9.0-rc.1", false},
+		{rng, "v1.11.1", false},
+		{literal, "bad-version", true},
+		{literal, "other", false},
+		{single, "", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.ret.Contains(tt.version); got != tt.expected {
+			t.Errorf("%+v.Contains(%q) = %v, want %v", *tt.ret, tt.version, got, tt.expected)
+		}
+	}
+
+	if single.IsRange() || !rng.IsRange() {
+		t.Errorf("Unexpected IsRange results: %v, %v", single.IsRange(), rng.IsRange())
+	}
+}
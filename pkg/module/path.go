@@ -0,0 +1,262 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/scagogogo/go-mod-parser/pkg/semver"
+)
+
+var (
+	// ErrInvalidMajorSuffix 表示模块路径的主版本后缀不合法，例如 /v1 或 /v02
+	ErrInvalidMajorSuffix = errors.New("invalid major version suffix")
+	// ErrMajorVersionMismatch 表示版本号与模块路径的主版本后缀不一致
+	ErrMajorVersionMismatch = errors.New("version does not match module path major version")
+	// ErrInvalidIncompatible 表示不允许使用+incompatible的版本号
+	ErrInvalidIncompatible = errors.New("invalid +incompatible version")
+	// ErrMalformedModulePath 表示模块路径不符合Go的模块路径规则
+	ErrMalformedModulePath = errors.New("malformed module path")
+	// ErrMalformedImportPath 表示导入路径不符合Go的导入路径规则
+	ErrMalformedImportPath = errors.New("malformed import path")
+)
+
+// SplitPathVersion 将模块路径拆分为前缀和主版本后缀
+// 例如 github.com/a/b/v2 拆分为 github.com/a/b 和 /v2，gopkg.in/yaml.v3 拆分为 gopkg.in/yaml 和 .v3；
+// 没有后缀时pathMajor为空。后缀不合法（例如 /v1、/v02、/v2.1）时ok为false
+func SplitPathVersion(path string) (prefix, pathMajor string, ok bool) {
+	if strings.HasPrefix(path, "gopkg.in/") {
+		return splitGopkgIn(path)
+	}
+
+	i := len(path)
+	dot := false
+	for i > 0 && ('0' <= path[i-1] && path[i-1] <= '9' || path[i-1] == '.') {
+		if path[i-1] == '.' {
+			dot = true
+		}
+		i--
+	}
+	if i <= 1 || i == len(path) || path[i-1] != 'v' || path[i-2] != '/' {
+		return path, "", true
+	}
+
+	prefix, pathMajor = path[:i-2], path[i-2:]
+	if dot || len(pathMajor) <= 2 || pathMajor[2] == '0' || pathMajor == "/v1" {
+		return path, "", false
+	}
+	return prefix, pathMajor, true
+}
+
+// splitGopkgIn 拆分gopkg.in路径，这类路径总是带有 .vN 形式的后缀（允许 .v0 和 .v1）
+func splitGopkgIn(path string) (prefix, pathMajor string, ok bool) {
+	if !strings.HasPrefix(path, "gopkg.in/") {
+		return path, "", false
+	}
+
+	i := len(path)
+	for i > 0 && '0' <= path[i-1] && path[i-1] <= '9' {
+		i--
+	}
+	if i <= 1 || path[i-1] != 'v' || path[i-2] != '.' {
+		return path, "", false
+	}
+
+	prefix, pathMajor = path[:i-2], path[i-2:]
+	if len(pathMajor) <= 2 || pathMajor[2] == '0' && pathMajor != ".v0" {
+		return path, "", false
+	}
+	return prefix, pathMajor, true
+}
+
+// PathMajorPrefix 返回主版本后缀对应的主版本，例如 /v2 和 .v2 都返回 "v2"，空后缀返回空字符串
+func PathMajorPrefix(pathMajor string) string {
+	if pathMajor == "" {
+		return ""
+	}
+	return pathMajor[1:]
+}
+
+// IsIncompatible 检查版本号是否带有+incompatible构建元数据
+// 这样的版本表示主版本不低于v2、但没有使用go.mod主版本后缀的模块
+func IsIncompatible(version string) bool {
+	return semver.Build(version) == "+incompatible"
+}
+
+// CheckPathMajor 检查版本号是否与模块路径的主版本后缀一致：
+// 带 /vN 或 .vN 后缀的路径只能使用主版本为vN的版本号，且不能带+incompatible；
+// 没有后缀的路径只能使用v0或v1，或者带+incompatible的v2及以上版本。
+// 不是语义化版本的版本号（例如replace中的分支名）不做检查
+func CheckPathMajor(version, path string) error {
+	_, pathMajor, ok := SplitPathVersion(path)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidMajorSuffix, path)
+	}
+	if !semver.IsValid(version) {
+		return nil
+	}
+
+	major := semver.Major(version)
+	incompatible := IsIncompatible(version)
+
+	switch {
+	case pathMajor == "":
+		if major == "v0" || major == "v1" {
+			if incompatible {
+				return fmt.Errorf("%w: %s has major version %s, +incompatible requires v2 or later", ErrInvalidIncompatible, version, major)
+			}
+			return nil
+		}
+		if !incompatible {
+			return fmt.Errorf("%w: %s has major version %s, path %s should end in /%s or version should be %s+incompatible",
+				ErrMajorVersionMismatch, version, major, path, major, semver.Canonical(version))
+		}
+		return nil
+
+	case incompatible:
+		return fmt.Errorf("%w: %s is not allowed for path %s with major version suffix %s", ErrInvalidIncompatible, version, path, pathMajor)
+
+	case pathMajor == ".v1" && strings.HasPrefix(version, "v0.0.0-"):
+		// 兼容早期go命令为gopkg.in的.v1路径生成的v0.0.0伪版本
+		return nil
+
+	case major != PathMajorPrefix(pathMajor):
+		return fmt.Errorf("%w: %s, path %s requires %s", ErrMajorVersionMismatch, version, path, PathMajorPrefix(pathMajor))
+	}
+	return nil
+}
+
+// badWindowsNames Windows上保留的文件名，不能作为路径元素（忽略大小写和扩展名）
+var badWindowsNames = []string{
+	"CON", "PRN", "AUX", "NUL",
+	"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+	"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9",
+}
+
+// pathKind 表示路径检查的规则集
+type pathKind int
+
+const (
+	// modulePath 模块路径：只允许ASCII字母、数字和 - . _ ~，元素不能以 . 开头
+	modulePath pathKind = iota
+
+	// importPath 导入路径：在模块路径的基础上还允许 +，元素可以以 . 开头
+	importPath
+)
+
+// CheckPath 按照go命令的规则检查require、exclude等声明中依赖的模块路径：
+// 路径由 / 分隔的非空元素组成，只能包含ASCII字母、数字和 - . _ ~，
+// 元素不能以 . 开头或结尾，不能是Windows保留名称（例如 CON、aux.go）或 ~ 加数字结尾的短文件名；
+// 第一个元素必须是包含 . 的小写域名，且不能以 - 开头；主版本后缀必须合法。
+// 错误包装了ErrMalformedModulePath，主版本后缀不合法时包装ErrInvalidMajorSuffix
+func CheckPath(path string) error {
+	if err := checkPath(path, modulePath); err != nil {
+		return fmt.Errorf("%w %q: %s", ErrMalformedModulePath, path, err)
+	}
+
+	first := path
+	if i := strings.Index(path, "/"); i >= 0 {
+		first = path[:i]
+	}
+	if !strings.Contains(first, ".") {
+		return fmt.Errorf("%w %q: missing dot in first path element", ErrMalformedModulePath, path)
+	}
+	for _, r := range first {
+		if !firstPathOK(r) {
+			return fmt.Errorf("%w %q: invalid char %q in first path element", ErrMalformedModulePath, path, r)
+		}
+	}
+	if _, _, ok := SplitPathVersion(path); !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidMajorSuffix, path)
+	}
+	return nil
+}
+
+// CheckImportPath 按照go命令的规则检查导入路径，例如module声明中的主模块路径和tool声明中的包路径
+// 规则与CheckPath相同，但不要求第一个元素是域名，允许 + 以及以 . 开头的元素，也不检查主版本后缀。
+// 错误包装了ErrMalformedImportPath
+func CheckImportPath(path string) error {
+	if err := checkPath(path, importPath); err != nil {
+		return fmt.Errorf("%w %q: %s", ErrMalformedImportPath, path, err)
+	}
+	return nil
+}
+
+// checkPath 检查路径整体以及每个元素，返回不带路径前缀的错误原因
+func checkPath(path string, kind pathKind) error {
+	if !utf8.ValidString(path) {
+		return errors.New("invalid UTF-8")
+	}
+	if path == "" {
+		return errors.New("empty string")
+	}
+	if path[0] == '-' {
+		return errors.New("leading dash")
+	}
+	if strings.Contains(path, "//") {
+		return errors.New("double slash")
+	}
+	if path[len(path)-1] == '/' {
+		return errors.New("trailing slash")
+	}
+
+	for _, elem := range strings.Split(path, "/") {
+		if err := checkElem(elem, kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkElem 检查路径中的单个元素
+func checkElem(elem string, kind pathKind) error {
+	if elem == "" {
+		return errors.New("empty path element")
+	}
+	if strings.Count(elem, ".") == len(elem) {
+		return fmt.Errorf("invalid path element %q", elem)
+	}
+	if elem[0] == '.' && kind == modulePath {
+		return errors.New("leading dot in path element")
+	}
+	if elem[len(elem)-1] == '.' {
+		return errors.New("trailing dot in path element")
+	}
+	for _, r := range elem {
+		if !modPathOK(r) && !(kind == importPath && r == '+') {
+			return fmt.Errorf("invalid char %q", r)
+		}
+	}
+
+	// Windows上不允许使用保留名称，即使带有扩展名
+	short := elem
+	if i := strings.Index(short, "."); i >= 0 {
+		short = short[:i]
+	}
+	for _, bad := range badWindowsNames {
+		if strings.EqualFold(bad, short) {
+			return fmt.Errorf("%q disallowed as path element component on Windows", short)
+		}
+	}
+
+	// 拒绝形如Windows短文件名的元素：~ 后跟数字结尾
+	if tilde := strings.LastIndexByte(short, '~'); tilde >= 0 && tilde < len(short)-1 {
+		suffix := short[tilde+1:]
+		if strings.Trim(suffix, "0123456789") == "" {
+			return errors.New("trailing tilde and digits in path element")
+		}
+	}
+	return nil
+}
+
+// modPathOK 检查字符是否可以出现在模块路径中
+func modPathOK(r rune) bool {
+	return r < utf8.RuneSelf && (r == '-' || r == '.' || r == '_' || r == '~' ||
+		'0' <= r && r <= '9' || 'A' <= r && r <= 'Z' || 'a' <= r && r <= 'z')
+}
+
+// firstPathOK 检查字符是否可以出现在模块路径的第一个元素（域名）中
+func firstPathOK(r rune) bool {
+	return r == '-' || r == '.' || '0' <= r && r <= '9' || 'a' <= r && r <= 'z'
+}
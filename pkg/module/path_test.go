@@ -0,0 +1,156 @@
+package module
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSplitPathVersion(t *testing.T) {
+	tests := []struct {
+		path      string
+		prefix    string
+		pathMajor string
+		ok        bool
+	}{
+		{"github.com/example/module", "github.com/example/module", "", true},
+		{"github.com/example/module/v2", "github.com/example/module", "/v2", true},
+		{"github.com/example/module/v10", "github.com/example/module", "/v10", true},
+		{"github.com/example/module/v1", "github.com/example/module/v1", "", false},
+		{"github.com/example/module/v0", "github.com/example/module/v0", "", false},
+		{"github.com/example/module/v02", "github.com/example/module/v02", "", false},
+		{"github.com/example/module/v2.1", "github.com/example/module/v2.1", "", false},
+		{"github.com/example/v2module", "github.com/example/v2module", "", true},
+		{"gopkg.in/yaml.v3", "gopkg.in/yaml", ".v3", true},
+		{"gopkg.in/check.v1", "gopkg.in/check", ".v1", true},
+		{"gopkg.in/old.v0", "gopkg.in/old", ".v0", true},
+		{"gopkg.in/yaml", "gopkg.in/yaml", "", false},
+		{"gopkg.in/yaml.v03", "gopkg.in/yaml.v03", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			prefix, pathMajor, ok := SplitPathVersion(tt.path)
+			if prefix != tt.prefix || pathMajor != tt.pathMajor || ok != tt.ok {
+				t.Errorf("SplitPathVersion(%q) = %q, %q, %v, want %q, %q, %v",
+					tt.path, prefix, pathMajor, ok, tt.prefix, tt.pathMajor, tt.ok)
+			}
+		})
+	}
+}
+
+func TestIsIncompatible(t *testing.T) {
+	if !IsIncompatible("v24.0.7+incompatible") {
+		t.Error("Expected v24.0.7+incompatible to be incompatible")
+	}
+	if IsIncompatible("v1.2.3") || IsIncompatible("v1.2.3+meta") || IsIncompatible("+incompatible") {
+		t.Error("Expected versions without +incompatible build metadata not to be incompatible")
+	}
+}
+
+func TestCheckPathMajor(t *testing.T) {
+	tests := []struct {
+		version string
+		path    string
+		err     error
+	}{
+		{"v1.2.3", "github.com/example/module", nil},
+		{"v0.1.0", "github.com/example/module", nil},
+		{"v24.0.7+incompatible", "github.com/docker/docker", nil},
+		{"v2.0.0", "github.com/example/module", ErrMajorVersionMismatch},
+		{"v1.2.3+incompatible", "github.com/example/module", ErrInvalidIncompatible},
+		{"v2.1.0", "github.com/example/module/v2", nil},
+		{"v2.0.0-20231010123456-abcdef123456", "github.com/example/module/v2", nil},
+		{"v3.0.0", "github.com/example/module/v2", ErrMajorVersionMismatch},
+		{"v1.0.0", "github.com/example/module/v2", ErrMajorVersionMismatch},
+		{"v2.0.0+incompatible", "github.com/example/module/v2", ErrInvalidIncompatible},
+		{"v3.0.1", "gopkg.in/yaml.v3", nil},
+		{"v2.4.0", "gopkg.in/yaml.v3", ErrMajorVersionMismatch},
+		{"v0.0.0-20161208181325-20d25e280405", "gopkg.in/check.v1", nil},
+		{"v1.0.0", "github.com/example/module/v1", ErrInvalidMajorSuffix},
+		{"master", "github.com/example/module/v2", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path+"@"+tt.version, func(t *testing.T) {
+			err := CheckPathMajor(tt.version, tt.path)
+			if tt.err == nil && err != nil {
+				t.Errorf("CheckPathMajor(%q, %q) returned error: %v", tt.version, tt.path, err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("CheckPathMajor(%q, %q) error = %v, want %v", tt.version, tt.path, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCheckPath(t *testing.T) {
+	tests := []struct {
+		path string
+		err  error
+	}{
+		{"github.com/example/module", nil},
+		{"github.com/example/module/v2", nil},
+		{"gopkg.in/yaml.v3", nil},
+		{"golang.org/x/text", nil},
+		{"example.com/a-b_c~d/e.f", nil},
+		{"", ErrMalformedModulePath},
+		{"github.com//foo", ErrMalformedModulePath},
+		{"github.com/foo/", ErrMalformedModulePath},
+		{"/github.com/foo", ErrMalformedModulePath},
+		{"-github.com/foo", ErrMalformedModulePath},
+		{"GITHUB.COM/foo", ErrMalformedModulePath},
+		{"github/foo", ErrMalformedModulePath},
+		{"mymodule", ErrMalformedModulePath},
+		{"github.com/.hidden", ErrMalformedModulePath},
+		{"github.com/foo./bar", ErrMalformedModulePath},
+		{"github.com/../bar", ErrMalformedModulePath},
+		{"github.com/foo bar", ErrMalformedModulePath},
+		{"github.com/foo+bar", ErrMalformedModulePath},
+		{"github.com/héllo", ErrMalformedModulePath},
+		{"github.com/example/con", ErrMalformedModulePath},
+		{"github.com/example/Aux.go", ErrMalformedModulePath},
+		{"github.com/example/LPT1", ErrMalformedModulePath},
+		{"github.com/example/PROGRA~1", ErrMalformedModulePath},
+		{"github.com/example/console", nil},
+		{"github.com/example/a~b", nil},
+		{"github.com/example/module/v1", ErrInvalidMajorSuffix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := CheckPath(tt.path)
+			if tt.err == nil && err != nil || tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("CheckPath(%q) = %v, want %v", tt.path, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCheckImportPath(t *testing.T) {
+	tests := []struct {
+		path  string
+		valid bool
+	}{
+		{"mymodule", true},
+		{"example/internal/tool", true},
+		{"github.com/example/module", true},
+		{"github.com/example/.config", true},
+		{"github.com/example/c++", true},
+		{"GitHub.com/Example/Module", true},
+		{"github.com/example/module/v1", true},
+		{"github.com//foo", false},
+		{"example/nul", false},
+		{"example/foo.", false},
+		{"example/foo bar", false},
+		{"-example", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := CheckImportPath(tt.path)
+			if tt.valid && err != nil || !tt.valid && !errors.Is(err, ErrMalformedImportPath) {
+				t.Errorf("CheckImportPath(%q) = %v, want valid=%v", tt.path, err, tt.valid)
+			}
+		})
+	}
+}
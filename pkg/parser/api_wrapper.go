@@ -1,8 +1,6 @@
 package parser
 
 import (
-	"bufio"
-	"fmt"
 	"io"
 	"strings"
 
@@ -11,59 +9,57 @@ import (
 )
 
 // ParseFromReader 从io.Reader解析go.mod文件
+// 语法错误以*ParseError的形式返回，可以通过errors.As获取出错的位置和指令
 func ParseFromReader(r io.Reader) (*module.Module, error) {
-	mod := &module.Module{
-		Requires: make([]*module.Require, 0),
-		Replaces: make([]*module.Replace, 0),
-		Excludes: make([]*module.Exclude, 0),
-		Retracts: make([]*module.Retract, 0),
-	}
-
-	scanner := bufio.NewScanner(r)
-	lineNum := 0
-	inBlock := false
-	blockType := ""
-
-	for scanner.Scan() {
-		lineNum++
-		line := strings.TrimSpace(scanner.Text())
-
-		// 跳过空行或注释
-		if line == "" || (strings.HasPrefix(line, "//") && !strings.Contains(line, "indirect")) {
-			continue
-		}
-
-		// 检查块结束
-		if inBlock && line == ")" {
-			inBlock = false
-			continue
-		}
-
-		// 检查块开始
-		if strings.HasSuffix(line, "(") {
-			inBlock = true
-			blockType = strings.TrimSpace(strings.TrimSuffix(line, "("))
-			continue
-		}
-
-		if inBlock {
-			if err := handleBlockLine(mod, blockType, line); err != nil {
-				return nil, fmt.Errorf("line %d: %w", lineNum, err)
-			}
-			continue
-		}
-
-		// 非块内容解析
-		if err := handleSingleLine(mod, line); err != nil {
-			return nil, fmt.Errorf("line %d: %w", lineNum, err)
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
+	return ParseWithOptions(r, Options{})
+}
+
+// Options 控制go.mod文件的解析行为
+type Options struct {
+	// FileName 文件名，用于在ParseError中标识出错的文件；
+	// 设置后本地目录替换的目标会相对于该文件所在目录解析，结果记录在ReplaceItem.Dir中
+	FileName string
+
+	// Recover 为true时跳过无法解析的语句并继续解析，
+	// 返回部分解析的模块以及包含所有诊断信息的ErrorList
+	Recover bool
+
+	// Validate 为true时在解析之后进行语义检查（见File.Validate），
+	// 发现的问题与语法错误一样以*ParseError的形式返回
+	Validate bool
+}
+
+// ParseWithOptions 按照指定选项从io.Reader解析go.mod文件
+// 默认遇到第一个语法错误即返回*ParseError；开启Recover后总是返回解析到的模块，
+// 存在语法错误时同时返回ErrorList
+func ParseWithOptions(r io.Reader, opts Options) (*module.Module, error) {
+	f, err := ParseSyntax(r, opts)
+	if f == nil {
 		return nil, err
 	}
+	return f.Module, err
+}
 
-	return mod, nil
+// directiveOf 返回语句的指令关键字，不是已知指令时返回空字符串
+func directiveOf(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	if _, ok := directiveErrors[fields[0]]; ok {
+		return fields[0]
+	}
+	return ""
+}
+
+// isBlockDirective 检查指令是否支持块形式
+func isBlockDirective(directive string) bool {
+	switch directive {
+	case "godebug", "require", "tool", "replace", "exclude", "retract":
+		return true
+	default:
+		return false
+	}
 }
 
 // ParseFromString 从字符串解析go.mod文件
@@ -72,8 +68,11 @@ func ParseFromString(s string) (*module.Module, error) {
 }
 
 // ParseFromFile 从文件解析go.mod文件
+// 本地目录替换的目标会相对于go.mod所在目录解析，结果记录在ReplaceItem.Dir中
 func ParseFromFile(path string) (*module.Module, error) {
-	return module.OpenAndProcess(path, ParseFromReader)
+	return module.OpenAndProcess(path, func(r io.Reader) (*module.Module, error) {
+		return ParseWithOptions(r, Options{FileName: path})
+	})
 }
 
 // handleSingleLine 处理单行语句
@@ -92,6 +91,20 @@ func handleSingleLine(mod *module.Module, line string) error {
 		return nil
 	}
 
+	// 尝试解析工具链
+	if handled, err := parseToolchain(mod, line); err != nil {
+		return err
+	} else if handled {
+		return nil
+	}
+
+	// 尝试解析单行godebug
+	if handled, err := parseGodebugSingleLine(mod, line); err != nil {
+		return err
+	} else if handled {
+		return nil
+	}
+
 	// 尝试解析单行require
 	if handled, err := parseRequireSingleLine(mod, line); err != nil {
 		return err
@@ -99,6 +112,13 @@ func handleSingleLine(mod *module.Module, line string) error {
 		return nil
 	}
 
+	// 尝试解析单行tool
+	if handled, err := parseToolSingleLine(mod, line); err != nil {
+		return err
+	} else if handled {
+		return nil
+	}
+
 	// 尝试解析单行replace
 	if handled, err := parseReplaceSingleLine(mod, line); err != nil {
 		return err
@@ -120,14 +140,22 @@ func handleSingleLine(mod *module.Module, line string) error {
 		return nil
 	}
 
-	return fmt.Errorf("unrecognized line format: %s", line)
+	// 关键字正确但参数无法解析时返回该指令对应的错误
+	if err, ok := directiveErrors[directiveOf(line)]; ok {
+		return err
+	}
+	return ErrUnrecognizedLine
 }
 
 // handleBlockLine 处理块内的语句
 func handleBlockLine(mod *module.Module, blockType, line string) error {
 	switch blockType {
+	case "godebug":
+		return parseGodebugBlockLine(mod, line)
 	case "require":
 		return parseRequireBlockLine(mod, line)
+	case "tool":
+		return parseToolBlockLine(mod, line)
 	case "replace":
 		return parseReplaceBlockLine(mod, line)
 	case "exclude":
@@ -135,7 +163,7 @@ func handleBlockLine(mod *module.Module, blockType, line string) error {
 	case "retract":
 		return parseRetractBlockLine(mod, line)
 	default:
-		return fmt.Errorf("unknown block type: %s", blockType)
+		return &tokenError{token: blockType, err: ErrUnknownBlock}
 	}
 }
 
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
@@ -461,3 +462,84 @@ invalid single line
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "line 3")
 }
+
+func TestParseWithOptions_Recover(t *testing.T) {
+	content := `module github.com/example/test
+
+go 1.21
+
+require github.com/example/broken
+
+require (
+	github.com/stretchr/testify v1.8.4
+	github.com/example/missing-version
+	github.com/pkg/errors v0.9.1
+)
+
+unknown (
+	whatever
+)
+
+invalid single line
+
+exclude github.com/bad/pkg v1.0.0
+`
+	mod, err := ParseWithOptions(strings.NewReader(content), Options{FileName: "go.mod", Recover: true})
+	assert.Error(t, err)
+	assert.NotNil(t, mod)
+
+	// 有效的语句仍然被解析
+	assert.Equal(t, "github.com/example/test", mod.Name)
+	assert.Equal(t, "1.21", mod.GoVersion)
+	assert.Len(t, mod.Requires, 2)
+	assert.Len(t, mod.Excludes, 1)
+
+	var list ErrorList
+	assert.True(t, errors.As(err, &list))
+	assert.Len(t, list, 4)
+	assert.Equal(t, 5, list[0].Line)
+	assert.ErrorIs(t, list[0], ErrInvalidRequire)
+	assert.Equal(t, 9, list[1].Line)
+	assert.ErrorIs(t, list[1], ErrInvalidRequire)
+	assert.Equal(t, 13, list[2].Line)
+	assert.ErrorIs(t, list[2], ErrUnknownBlock)
+	assert.Equal(t, 17, list[3].Line)
+	assert.ErrorIs(t, list[3], ErrUnrecognizedLine)
+	for _, parseErr := range list {
+		assert.Equal(t, "go.mod", parseErr.File)
+	}
+
+	assert.ErrorIs(t, err, ErrUnknownBlock)
+	assert.Contains(t, err.Error(), "(and 3 more errors)")
+}
+
+func TestParseWithOptions_RecoverUnclosedBlock(t *testing.T) {
+	content := `module github.com/example/test
+
+require (
+	github.com/stretchr/testify v1.8.4
+`
+	mod, err := ParseWithOptions(strings.NewReader(content), Options{Recover: true})
+	assert.ErrorIs(t, err, ErrUnclosedBlock)
+	assert.Len(t, mod.Requires, 1)
+}
+
+func TestParseWithOptions_RecoverNoErrors(t *testing.T) {
+	mod, err := ParseWithOptions(strings.NewReader("module github.com/example/test\n"), Options{Recover: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/example/test", mod.Name)
+}
+
+func TestParseWithOptions_StopsAtFirstError(t *testing.T) {
+	content := `module github.com/example/test
+
+invalid line one
+invalid line two
+`
+	mod, err := ParseWithOptions(strings.NewReader(content), Options{})
+	assert.Nil(t, mod)
+
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, 3, parseErr.Line)
+}
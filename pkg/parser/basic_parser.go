@@ -1,24 +1,128 @@
 package parser
 
 import (
+	"strconv"
 	"strings"
 
+	"github.com/scagogogo/go-mod-parser/pkg/gover"
 	"github.com/scagogogo/go-mod-parser/pkg/module"
 )
 
+// parseString 解析可能带引号的词法单元
+// go.mod语法允许使用Go的解释型字符串（"..."）和原始字符串（`...`）字面量，
+// 未加引号的词法单元原样返回
+func parseString(token string) (string, error) {
+	if token == "" || (token[0] != '"' && token[0] != '`') {
+		return token, nil
+	}
+	value, err := strconv.Unquote(token)
+	if err != nil {
+		return "", &tokenError{token: token, err: ErrInvalidQuotedString}
+	}
+	return value, nil
+}
+
+// parseStrings 依次解析多个可能带引号的词法单元
+func parseStrings(tokens ...string) ([]string, error) {
+	values := make([]string, len(tokens))
+	for i, token := range tokens {
+		value, err := parseString(token)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// splitFields 按空白切分语句，带引号的字符串即使包含空白也作为一个整体
+func splitFields(line string) []string {
+	var fields []string
+	i := 0
+	for i < len(line) {
+		if isSpace(line[i]) {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(line) && !isSpace(line[j]) {
+			quote := line[j]
+			j++
+			if quote != '"' && quote != '`' {
+				continue
+			}
+			// 跳到闭合的引号之后，未闭合时取到行尾
+			for j < len(line) && line[j] != quote {
+				if quote == '"' && line[j] == '\\' && j+1 < len(line) {
+					j++
+				}
+				j++
+			}
+			if j < len(line) {
+				j++
+			}
+		}
+		fields = append(fields, line[i:j])
+		i = j
+	}
+	return fields
+}
+
 // parseModuleName 解析模块名称
 func parseModuleName(mod *module.Module, line string) (bool, error) {
 	if matches := moduleRegexp.FindStringSubmatch(line); len(matches) == 2 {
-		mod.Name = matches[1]
+		name, err := parseString(matches[1])
+		if err != nil {
+			return false, err
+		}
+		mod.Name = name
 		return true, nil
 	}
 	return false, nil
 }
 
-// parseGoVersion 解析Go版本
+// parseDeprecation 根据module声明前的注释和行尾注释解析弃用说明
+// 按照go.mod规范，注释中以 "Deprecated:" 开头的段落为弃用说明，段落之间以空注释行分隔
+func parseDeprecation(comments []string, line string) string {
+	lines := make([]string, 0, len(comments)+1)
+	for _, comment := range comments {
+		lines = append(lines, strings.TrimSpace(strings.TrimPrefix(comment, "//")))
+	}
+	if i := strings.Index(line, "//"); i >= 0 {
+		lines = append(lines, strings.TrimSpace(line[i+len("//"):]))
+	}
+
+	if matches := deprecatedRegexp.FindStringSubmatch(strings.Join(lines, "\n")); len(matches) == 2 {
+		return matches[1]
+	}
+	return ""
+}
+
+// parseGoVersion 解析Go版本，版本必须是 1.21、1.21.0、1.21rc1 这样的合法Go版本
 func parseGoVersion(mod *module.Module, line string) (bool, error) {
 	if matches := goRegexp.FindStringSubmatch(line); len(matches) == 2 {
-		mod.GoVersion = matches[1]
+		version, err := parseString(matches[1])
+		if err != nil {
+			return false, err
+		}
+		if !gover.IsValid(version) {
+			return false, &tokenError{token: matches[1], err: ErrInvalidGoVersion}
+		}
+		mod.GoVersion = version
+		return true, nil
+	}
+	return false, nil
+}
+
+// parseToolchain 解析toolchain声明
+func parseToolchain(mod *module.Module, line string) (bool, error) {
+	if matches := toolchainRegexp.FindStringSubmatch(line); len(matches) == 2 {
+		toolchain, err := parseString(matches[1])
+		if err != nil {
+			return false, err
+		}
+		mod.Toolchain = toolchain
 		return true, nil
 	}
 	return false, nil
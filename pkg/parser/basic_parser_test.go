@@ -1,12 +1,116 @@
 package parser
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/scagogogo/go-mod-parser/pkg/module"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "unquoted token",
+			token:    "github.com/example/module",
+			expected: "github.com/example/module",
+		},
+		{
+			name:     "interpreted string",
+			token:    `"github.com/example/module"`,
+			expected: "github.com/example/module",
+		},
+		{
+			name:     "interpreted string with escape",
+			token:    `"github.com/example/\x6dodule"`,
+			expected: "github.com/example/module",
+		},
+		{
+			name:     "raw string",
+			token:    "`github.com/example/module`",
+			expected: "github.com/example/module",
+		},
+		{
+			name:        "unterminated interpreted string",
+			token:       `"github.com/example/module`,
+			expectError: true,
+		},
+		{
+			name:        "unterminated raw string",
+			token:       "`github.com/example/module",
+			expectError: true,
+		},
+		{
+			name:        "invalid escape",
+			token:       `"github.com/\q"`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := parseString(tt.token)
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrInvalidQuotedString)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestSplitFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected []string
+	}{
+		{
+			name:     "plain fields",
+			line:     "  github.com/example/module\tv1.0.0 // indirect",
+			expected: []string{"github.com/example/module", "v1.0.0", "//", "indirect"},
+		},
+		{
+			name:     "quoted string with spaces",
+			line:     `"github.com/example/with space" v1.0.0`,
+			expected: []string{`"github.com/example/with space"`, "v1.0.0"},
+		},
+		{
+			name:     "escaped quote",
+			line:     `"a\" b" v1.0.0`,
+			expected: []string{`"a\" b"`, "v1.0.0"},
+		},
+		{
+			name:     "raw string",
+			line:     "`raw path` v1.0.0",
+			expected: []string{"`raw path`", "v1.0.0"},
+		},
+		{
+			name:     "unterminated string",
+			line:     `"github.com/example v1.0.0`,
+			expected: []string{`"github.com/example v1.0.0`},
+		},
+		{
+			name:     "empty line",
+			line:     "   ",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, splitFields(tt.line))
+		})
+	}
+}
+
 func TestParseModuleName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -26,6 +130,18 @@ func TestParseModuleName(t *testing.T) {
 			expected: "",
 			handled:  false,
 		},
+		{
+			name:     "quoted module declaration",
+			line:     `module "github.com/example/module"`,
+			expected: "github.com/example/module",
+			handled:  true,
+		},
+		{
+			name:     "module declaration with trailing comment",
+			line:     "module github.com/example/module // Deprecated: use v2",
+			expected: "github.com/example/module",
+			handled:  true,
+		},
 		{
 			name:     "invalid module declaration - extra tokens",
 			line:     "module github.com/example/module extra",
@@ -51,6 +167,63 @@ func TestParseModuleName(t *testing.T) {
 	}
 }
 
+func TestParseDeprecation(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []string
+		line     string
+		expected string
+	}{
+		{
+			name:     "leading deprecation comment",
+			comments: []string{"// Deprecated: use example.com/v2 instead."},
+			line:     "module example.com",
+			expected: "use example.com/v2 instead.",
+		},
+		{
+			name:     "trailing deprecation comment",
+			line:     "module example.com // Deprecated: use example.com/v2",
+			expected: "use example.com/v2",
+		},
+		{
+			name: "deprecation paragraph after other paragraph",
+			comments: []string{
+				"// Package example does things.",
+				"//",
+				"// Deprecated: no longer maintained.",
+			},
+			line:     "module example.com",
+			expected: "no longer maintained.",
+		},
+		{
+			name: "multi-line deprecation paragraph",
+			comments: []string{
+				"// Deprecated: use example.com/v2",
+				"// which has a better API.",
+			},
+			line:     "module example.com",
+			expected: "use example.com/v2\nwhich has a better API.",
+		},
+		{
+			name:     "deprecated not at paragraph start",
+			comments: []string{"// This is not Deprecated: really"},
+			line:     "module example.com",
+			expected: "",
+		},
+		{
+			name:     "no comments",
+			line:     "module example.com",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseDeprecation(tt.comments, tt.line))
+		})
+	}
+}
+
 func TestParseGoVersion(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -82,6 +255,18 @@ func TestParseGoVersion(t *testing.T) {
 			expected: "",
 			handled:  false,
 		},
+		{
+			name:     "valid go version with prerelease",
+			line:     "go 1.21rc1",
+			expected: "1.21rc1",
+			handled:  true,
+		},
+		{
+			name:     "valid go version with patch prerelease",
+			line:     "go 1.21.0rc1",
+			expected: "1.21.0rc1",
+			handled:  true,
+		},
 		{
 			name:     "not a go version declaration",
 			line:     "module github.com/example/module",
@@ -101,6 +286,70 @@ func TestParseGoVersion(t *testing.T) {
 	}
 }
 
+func TestParseGoVersion_Invalid(t *testing.T) {
+	for _, line := range []string{"go 1", "go 1.21.x", "go v1.21", "go go1.21", "go 1.021", `go "latest"`} {
+		t.Run(line, func(t *testing.T) {
+			mod := &module.Module{}
+			handled, err := parseGoVersion(mod, line)
+			assert.False(t, handled)
+			assert.ErrorIs(t, err, ErrInvalidGoVersion)
+			assert.Empty(t, mod.GoVersion)
+		})
+	}
+
+	_, err := ParseFromString("module github.com/example/module\n\ngo 1.21.x\n")
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, 3, parseErr.Line)
+	assert.Equal(t, 4, parseErr.Column)
+	assert.Equal(t, "go", parseErr.Directive)
+	assert.Equal(t, "1.21.x", parseErr.Text)
+}
+
+func TestParseToolchain(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+		handled  bool
+	}{
+		{
+			name:     "valid toolchain",
+			line:     "toolchain go1.21.5",
+			expected: "go1.21.5",
+			handled:  true,
+		},
+		{
+			name:     "toolchain with custom suffix",
+			line:     "toolchain go1.22.0-custom",
+			expected: "go1.22.0-custom",
+			handled:  true,
+		},
+		{
+			name:     "invalid toolchain - extra tokens",
+			line:     "toolchain go1.21.5 extra",
+			expected: "",
+			handled:  false,
+		},
+		{
+			name:     "not a toolchain declaration",
+			line:     "go 1.21",
+			expected: "",
+			handled:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mod := &module.Module{}
+			handled, err := parseToolchain(mod, tt.line)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.handled, handled)
+			assert.Equal(t, tt.expected, mod.Toolchain)
+		})
+	}
+}
+
 func TestIsIndirect(t *testing.T) {
 	tests := []struct {
 		name     string
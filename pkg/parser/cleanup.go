@@ -0,0 +1,226 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/go-mod-parser/pkg/semver"
+)
+
+// cleanSyntax 按照 go mod edit -fmt 的方式整理语法树，返回新的语法树，不修改f
+// 与go命令的SortBlocks和Cleanup一致：去掉重复的exclude、replace和tool语句，块内语句排序，
+// 去掉空块，只有一条语句且右括号之前没有注释的块写为单行指令；
+// 与go命令不同，空块上的注释保留为独立的注释段，单行化时左右括号后的注释保留为该语句的行尾注释
+func cleanSyntax(f *FileSyntax) *FileSyntax {
+	kill := duplicateLines(f)
+	semanticExclude := semver.Compare("v"+syntaxGoVersion(f), "v1.21") >= 0
+
+	clean := *f
+	clean.Stmt = make([]Expr, 0, len(f.Stmt))
+	for _, stmt := range f.Stmt {
+		switch x := stmt.(type) {
+		case *Line:
+			if kill[x] {
+				continue
+			}
+		case *LineBlock:
+			lines := make([]*Line, 0, len(x.Line))
+			for _, line := range x.Line {
+				if !kill[line] {
+					lines = append(lines, line)
+				}
+			}
+			switch {
+			case len(lines) == 0:
+				if comments := blockComments(x); len(comments) > 0 {
+					clean.Stmt = append(clean.Stmt, &CommentBlock{Comments: Comments{Before: comments}, Start: x.Start})
+				}
+				continue
+			case len(lines) == 1 && len(x.RParen.Before) == 0:
+				stmt = collapsedLine(x, lines[0])
+			default:
+				compare := lineComparer(x.Token, semanticExclude)
+				sort.SliceStable(lines, func(i, j int) bool {
+					return compare(lines[i], lines[j]) < 0
+				})
+				block := *x
+				block.Line = lines
+				stmt = &block
+			}
+		}
+		clean.Stmt = append(clean.Stmt, stmt)
+	}
+	return &clean
+}
+
+// syntaxGoVersion 返回语法树中go声明的版本，没有go声明时返回空字符串
+func syntaxGoVersion(f *FileSyntax) string {
+	for _, stmt := range f.Stmt {
+		if line, ok := stmt.(*Line); ok && len(line.Token) == 2 && line.Token[0] == "go" {
+			if version, err := parseString(line.Token[1]); err == nil {
+				return version
+			}
+		}
+	}
+	return ""
+}
+
+// duplicateLines 返回与go命令一样需要去掉的重复语句
+// 重复的exclude和tool保留第一条，同一被替换模块版本的replace保留最后一条；require和retract不去重
+func duplicateLines(f *FileSyntax) map[*Line]bool {
+	kill := make(map[*Line]bool)
+	seen := make(map[string]bool)
+	var replaces []*Line
+	replaceKeys := make(map[*Line]string)
+
+	visit := func(keyword string, line *Line, tokens []string) {
+		args := make([]string, len(tokens))
+		for i, token := range tokens {
+			args[i] = token
+			if value, err := parseString(token); err == nil {
+				args[i] = value
+			}
+		}
+
+		switch keyword {
+		case "exclude", "tool":
+			if keyword == "exclude" && len(args) != 2 || keyword == "tool" && len(args) != 1 {
+				return
+			}
+			key := keyword + "\x00" + strings.Join(args, "\x00")
+			if seen[key] {
+				kill[line] = true
+			}
+			seen[key] = true
+		case "replace":
+			for i, arg := range args {
+				if arg == "=>" && (i == 1 || i == 2) {
+					replaces = append(replaces, line)
+					replaceKeys[line] = strings.Join(args[:i], "\x00")
+					break
+				}
+			}
+		}
+	}
+	for _, stmt := range f.Stmt {
+		switch x := stmt.(type) {
+		case *Line:
+			if len(x.Token) > 0 {
+				visit(x.Token[0], x, x.Token[1:])
+			}
+		case *LineBlock:
+			if len(x.Token) == 1 {
+				for _, line := range x.Line {
+					visit(x.Token[0], line, line.Token)
+				}
+			}
+		}
+	}
+
+	seenReplace := make(map[string]bool)
+	for i := len(replaces) - 1; i >= 0; i-- {
+		key := replaceKeys[replaces[i]]
+		if seenReplace[key] {
+			kill[replaces[i]] = true
+		}
+		seenReplace[key] = true
+	}
+	return kill
+}
+
+// lineComparer 返回块内语句的比较函数，与go命令的SortBlocks一致
+// 一般的块按词法单元的原文逐个比较；go 1.21及以后的exclude块按模块路径和语义化版本排序；
+// retract块按版本区间从高到低排序；require块与ConsolidateRequires一致，
+// 同一模块路径的多个版本按语义化版本排序，不同模块路径的顺序与go命令相同
+func lineComparer(keyword []string, semanticExclude bool) func(a, b *Line) int {
+	switch {
+	case len(keyword) == 1 && keyword[0] == "require":
+		return compareModuleLines
+	case len(keyword) == 1 && keyword[0] == "exclude" && semanticExclude:
+		return compareModuleLines
+	case len(keyword) == 1 && keyword[0] == "retract":
+		return compareRetractLines
+	}
+	return compareLines
+}
+
+// compareLines 按词法单元的原文逐个比较两条语句
+func compareLines(a, b *Line) int {
+	for i := 0; i < len(a.Token) && i < len(b.Token); i++ {
+		if c := strings.Compare(a.Token[i], b.Token[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(a.Token), len(b.Token))
+}
+
+// compareModuleLines 按模块路径和语义化版本比较两条exclude或require语句，格式不正确时按原文比较
+func compareModuleLines(a, b *Line) int {
+	if len(a.Token) != 2 || len(b.Token) != 2 {
+		return compareLines(a, b)
+	}
+	if c := strings.Compare(a.Token[0], b.Token[0]); c != 0 {
+		return c
+	}
+	if c := semver.Compare(a.Token[1], b.Token[1]); c != 0 {
+		return c
+	}
+	return compareLines(a, b)
+}
+
+// compareRetractLines 按版本区间从高到低比较两条retract语句，先比较下界再比较上界
+// 单个版本视为上下界相同的区间，格式不正确的语句视为不合法的版本
+func compareRetractLines(a, b *Line) int {
+	interval := func(line *Line) (low, high string) {
+		switch {
+		case len(line.Token) == 1:
+			return line.Token[0], line.Token[0]
+		case len(line.Token) == 5 && line.Token[0] == "[" && line.Token[2] == "," && line.Token[4] == "]":
+			return line.Token[1], line.Token[3]
+		}
+		return "", ""
+	}
+	lowA, highA := interval(a)
+	lowB, highB := interval(b)
+	if c := semver.Compare(lowA, lowB); c != 0 {
+		return -c
+	}
+	return -semver.Compare(highA, highB)
+}
+
+// compareInts 比较两个整数，x小于、等于、大于y时分别返回-1、0、1
+func compareInts(x, y int) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// collapsedLine 返回将只有一条语句的块写为单行指令后的语句，不修改原有语句
+func collapsedLine(block *LineBlock, line *Line) *Line {
+	collapsed := *line
+	collapsed.Comments = Comments{
+		Before: append(append([]Comment{}, block.Before...), line.Before...),
+		Suffix: append(append(append([]Comment{}, line.Suffix...), block.LParen.Suffix...), block.RParen.Suffix...),
+		After:  append(append([]Comment{}, line.After...), block.After...),
+	}
+	collapsed.Token = append(append([]string{}, block.Token...), line.Token...)
+	collapsed.tokenPos = nil
+	collapsed.InBlock = false
+	return &collapsed
+}
+
+// blockComments 返回块上除空行以外的所有注释，均作为整行注释
+func blockComments(block *LineBlock) []Comment {
+	var comments []Comment
+	for _, group := range [][]Comment{block.Before, block.LParen.Suffix, block.RParen.Before, block.RParen.Suffix, block.After} {
+		for _, comment := range commentLines(group) {
+			comment.Suffix = false
+			comments = append(comments, comment)
+		}
+	}
+	return comments
+}
@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"sort"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/scagogogo/go-mod-parser/pkg/semver"
+)
+
+// ConsolidateRequires 按照go 1.17及以后的go命令的方式整理require语句
+// 分散的单行require和多个require块合并为一个直接依赖块和一个间接依赖块，
+// 两个块位于第一条require语句处，块内按模块路径和语义化版本排序；
+// 只有一项的块写为单行指令。语句上的注释随语句移动，Module.Requires的顺序同步更新
+func (f *File) ConsolidateRequires() {
+	consolidateRequires(f.Syntax)
+	f.sortRequires()
+}
+
+// sortRequires 按照语句在语法树中的顺序重新排列Module.Requires
+func (f *File) sortRequires() {
+	byLine := make(map[*Line]*module.Require, len(f.Module.Requires))
+	for _, req := range f.Module.Requires {
+		if line := f.Line(req); line != nil {
+			byLine[line] = req
+		}
+	}
+
+	requires := make([]*module.Require, 0, len(f.Module.Requires))
+	for _, stmt := range f.Syntax.Stmt {
+		switch x := stmt.(type) {
+		case *Line:
+			if req, ok := byLine[x]; ok {
+				requires = append(requires, req)
+				delete(byLine, x)
+			}
+		case *LineBlock:
+			for _, line := range x.Line {
+				if req, ok := byLine[line]; ok {
+					requires = append(requires, req)
+					delete(byLine, line)
+				}
+			}
+		}
+	}
+	// 没有对应语句的依赖保持原有顺序放在最后
+	for _, req := range f.Module.Requires {
+		if line := f.Line(req); line == nil || byLine[line] == req {
+			requires = append(requires, req)
+		}
+	}
+	f.Module.Requires = requires
+}
+
+// consolidateRequires 将语法树中的require语句整理为直接依赖块和间接依赖块
+func consolidateRequires(fs *FileSyntax) {
+	var direct, indirect []*Line
+	var before, after []Comment
+	insert := -1
+
+	add := func(line *Line) {
+		if isIndirect(lineText(line)) {
+			indirect = append(indirect, line)
+		} else {
+			direct = append(direct, line)
+		}
+	}
+
+	stmts := make([]Expr, 0, len(fs.Stmt))
+	for _, stmt := range fs.Stmt {
+		switch x := stmt.(type) {
+		case *Line:
+			if len(x.Token) > 0 && x.Token[0] == "require" {
+				if insert < 0 {
+					insert = len(stmts)
+				}
+				x.Token = x.Token[1:]
+				if len(x.tokenPos) > 0 {
+					x.tokenPos = x.tokenPos[1:]
+				}
+				x.InBlock = true
+				add(x)
+				continue
+			}
+		case *LineBlock:
+			if len(x.Token) == 1 && x.Token[0] == "require" {
+				if insert < 0 {
+					insert = len(stmts)
+				}
+				// 块上的注释保留在整理后的块上
+				before = append(before, x.Before...)
+				before = append(before, x.LParen.Suffix...)
+				after = append(after, commentLines(x.RParen.Before)...)
+				// 右括号后的行尾注释写为整行注释，与块内最后的注释放在一起
+				for _, comment := range x.RParen.Suffix {
+					comment.Suffix = false
+					after = append(after, comment)
+				}
+				after = append(after, x.After...)
+				for _, line := range x.Line {
+					add(line)
+				}
+				continue
+			}
+		}
+		stmts = append(stmts, stmt)
+	}
+	if insert < 0 {
+		return
+	}
+
+	var groups []Expr
+	for _, lines := range [][]*Line{direct, indirect} {
+		if len(lines) == 0 {
+			continue
+		}
+		sortRequireLines(lines)
+		block := &LineBlock{Token: []string{"require"}, Line: lines}
+		if len(groups) == 0 {
+			block.Before = commentLines(before)
+		}
+		groups = append(groups, block)
+	}
+	if len(groups) == 0 {
+		return
+	}
+	last := groups[len(groups)-1].(*LineBlock)
+	last.RParen.Before = after
+	for i, group := range groups {
+		if block := group.(*LineBlock); len(block.Line) == 1 {
+			groups[i] = collapseBlock(block)
+		}
+	}
+
+	result := make([]Expr, 0, len(stmts)+len(groups))
+	result = append(result, stmts[:insert]...)
+	result = append(result, groups...)
+	fs.Stmt = append(result, stmts[insert:]...)
+}
+
+// sortRequireLines 将块内的require语句按模块路径和语义化版本排序
+// 块内第一条语句之前的空行不再有意义，排序后去掉
+func sortRequireLines(lines []*Line) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		pi, vi := requireLineKey(lines[i])
+		pj, vj := requireLineKey(lines[j])
+		if pi != pj {
+			return pi < pj
+		}
+		if c := semver.Compare(vi, vj); c != 0 {
+			return c < 0
+		}
+		return vi < vj
+	})
+	for _, line := range lines {
+		line.Before = trimLeadingBlankComments(line.Before)
+	}
+}
+
+// requireLineKey 返回require语句的模块路径和版本，带引号的词法单元先去掉引号
+func requireLineKey(line *Line) (path, version string) {
+	values := make([]string, 2)
+	for i := 0; i < 2 && i < len(line.Token); i++ {
+		values[i] = line.Token[i]
+		if value, err := parseString(line.Token[i]); err == nil {
+			values[i] = value
+		}
+	}
+	return values[0], values[1]
+}
+
+// trimLeadingBlankComments 去掉开头表示空行的注释
+func trimLeadingBlankComments(comments []Comment) []Comment {
+	for len(comments) > 0 && comments[0].Token == "" {
+		comments = comments[1:]
+	}
+	return comments
+}
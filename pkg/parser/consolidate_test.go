@@ -0,0 +1,186 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_ConsolidateRequires(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name: "merge and split scattered requires",
+			content: `module github.com/example/module
+
+go 1.21
+
+require golang.org/x/text v0.12.0 // indirect
+
+// main dependencies
+require (
+	github.com/stretchr/testify v1.8.4
+	// yaml is only used by testify
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+require github.com/davecgh/go-spew v1.1.1
+
+exclude golang.org/x/net v0.1.0
+`,
+			expected: `module github.com/example/module
+
+go 1.21
+
+// main dependencies
+require (
+	github.com/davecgh/go-spew v1.1.1
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	golang.org/x/text v0.12.0 // indirect
+	// yaml is only used by testify
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+exclude golang.org/x/net v0.1.0
+`,
+		},
+		{
+			name: "single entry groups become single lines",
+			content: `module github.com/example/module
+
+require (
+	golang.org/x/text v0.12.0 // indirect
+	github.com/stretchr/testify v1.8.4
+)
+`,
+			expected: `module github.com/example/module
+
+require github.com/stretchr/testify v1.8.4
+
+require golang.org/x/text v0.12.0 // indirect
+`,
+		},
+		{
+			name: "keep comment after closing paren",
+			content: `module github.com/example/module
+
+require (
+	github.com/example/x v1.0.0
+	github.com/example/y v1.2.0
+) // blk
+
+require github.com/example/z v1.0.0
+`,
+			expected: `module github.com/example/module
+
+require (
+	github.com/example/x v1.0.0
+	github.com/example/y v1.2.0
+	github.com/example/z v1.0.0
+// blk
+)
+`,
+		},
+		{
+			name: "keep comment after closing paren of single entry block",
+			content: `module github.com/example/module
+
+require (
+	github.com/example/y v1.2.0
+) // blk
+`,
+			expected: `module github.com/example/module
+
+require github.com/example/y v1.2.0
+// blk
+`,
+		},
+		{
+			name: "sort by path then semantic version",
+			content: `module github.com/example/module
+
+require (
+	github.com/example/dep v1.10.0
+	github.com/example/dep v1.9.0
+	github.com/example/dep v1.10.0-rc.1
+	github.com/example/a v2.0.0+incompatible
+)
+`,
+			expected: `module github.com/example/module
+
+require (
+	github.com/example/a v2.0.0+incompatible
+	github.com/example/dep v1.9.0
+	github.com/example/dep v1.10.0-rc.1
+	github.com/example/dep v1.10.0
+)
+`,
+		},
+		{
+			name:     "no requires",
+			content:  "module github.com/example/module\n\ngo 1.21\n",
+			expected: "module github.com/example/module\n\ngo 1.21\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseSyntaxFromString(tt.content)
+			require.NoError(t, err)
+
+			f.ConsolidateRequires()
+			assert.Equal(t, tt.expected, string(f.Format()))
+
+			// 模块信息的顺序与整理后的文件一致
+			reparsed, err := ParseSyntaxFromString(tt.expected)
+			require.NoError(t, err)
+			assert.Equal(t, reparsed.Module, f.Module)
+			for _, req := range f.Module.Requires {
+				assert.NotNil(t, f.Line(req))
+			}
+		})
+	}
+}
+
+func TestFormatModuleWithOptions(t *testing.T) {
+	mod := &module.Module{
+		Name:      "github.com/example/module",
+		GoVersion: "1.21",
+		Requires: []*module.Require{
+			{Path: "golang.org/x/text", Version: "v0.12.0", Indirect: true},
+			{Path: "github.com/stretchr/testify", Version: "v1.8.4"},
+			{Path: "github.com/davecgh/go-spew", Version: "v1.1.1", Indirect: true},
+		},
+	}
+
+	assert.Equal(t, `module github.com/example/module
+
+go 1.21
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/text v0.12.0 // indirect
+)
+`, string(FormatModuleWithOptions(mod, FormatOptions{})))
+
+	assert.Equal(t, `module github.com/example/module
+
+go 1.21
+
+require github.com/stretchr/testify v1.8.4
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	golang.org/x/text v0.12.0 // indirect
+)
+`, string(FormatModuleWithOptions(mod, FormatOptions{ConsolidateRequires: true})))
+}
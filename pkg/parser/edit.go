@@ -0,0 +1,528 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/go-mod-parser/pkg/gover"
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+)
+
+// AddRequire 添加一个直接依赖，依赖已存在时只更新其版本
+// 新的依赖追加到最后一个包含直接依赖的require块中，不会放入只有间接依赖的块；
+// 规则见addRequireLine
+func (f *File) AddRequire(path, version string) error {
+	if path == "" || version == "" {
+		return ErrInvalidRequire
+	}
+	if GetRequire(f.Module, path) != nil {
+		return f.SetRequireVersion(path, version)
+	}
+
+	req := &module.Require{Path: path, Version: version}
+	f.Module.Requires = append(f.Module.Requires, req)
+	f.lines[req] = f.addRequireLine(requireLine(req), false)
+	f.sortRequires()
+	return nil
+}
+
+// SetRequireVersion 修改依赖的版本，只改写版本这一个词法单元，保留语句的注释
+func (f *File) SetRequireVersion(path, version string) error {
+	if version == "" {
+		return ErrInvalidRequire
+	}
+	req := GetRequire(f.Module, path)
+	if req == nil {
+		return fmt.Errorf("%w: %s", ErrRequireNotFound, path)
+	}
+
+	req.Version = version
+	if line := f.Line(req); line != nil {
+		line.Token[len(line.Token)-1] = autoQuote(version)
+	}
+	return nil
+}
+
+// DropRequire 删除某个路径的所有依赖声明
+func (f *File) DropRequire(path string) error {
+	requires := f.Module.Requires[:0]
+	found := false
+	for _, req := range f.Module.Requires {
+		if req.Path != path {
+			requires = append(requires, req)
+			continue
+		}
+		found = true
+		f.dropEntry(req, false)
+	}
+	f.Module.Requires = requires
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrRequireNotFound, path)
+	}
+	return nil
+}
+
+// SetIndirect 设置依赖是否为间接依赖，通过增删行尾的 // indirect 注释实现
+// 行尾已有其他注释时按照go命令的习惯写为 // indirect; 原注释
+// go 1.17及以后的文件将直接依赖和间接依赖分块存放：语句所在的块中其他依赖都属于另一类时，
+// 语句移到同类依赖的块中（规则见addRequireLine），例如AddRequire之后标记为间接依赖
+func (f *File) SetIndirect(path string, indirect bool) error {
+	req := GetRequire(f.Module, path)
+	if req == nil {
+		return fmt.Errorf("%w: %s", ErrRequireNotFound, path)
+	}
+	if req.Indirect == indirect {
+		return nil
+	}
+
+	req.Indirect = indirect
+	if line := f.Line(req); line != nil {
+		setIndirectComment(line, indirect)
+		if SupportsFeature(f.Module, gover.FeatureLazyLoading) {
+			f.moveRequireLine(line, indirect)
+		}
+	}
+	return nil
+}
+
+// AddReplace 添加一条替换规则，旧模块路径和版本相同的规则已存在时改写其替换目标
+// oldVersion为空表示替换所有版本；替换目标为本地目录时newVersion必须为空，否则必须指定版本
+func (f *File) AddReplace(oldPath, oldVersion, newPath, newVersion string) error {
+	if oldPath == "" || newPath == "" || module.IsLocalPath(newPath) != (newVersion == "") {
+		return ErrInvalidReplace
+	}
+
+	for _, rep := range f.Module.Replaces {
+		if rep.Old.Path == oldPath && rep.Old.Version == oldVersion {
+			rep.New = &module.ReplaceItem{Path: newPath, Version: newVersion}
+			if line := f.Line(rep); line != nil {
+				setLineTokens(line, replaceLine(rep).Token)
+			}
+			return nil
+		}
+	}
+
+	rep := &module.Replace{
+		Old: &module.ReplaceItem{Path: oldPath, Version: oldVersion},
+		New: &module.ReplaceItem{Path: newPath, Version: newVersion},
+	}
+	f.Module.Replaces = append(f.Module.Replaces, rep)
+	f.lines[rep] = f.addLine("replace", replaceLine(rep))
+	return nil
+}
+
+// DropReplace 删除旧模块路径和版本都相同的替换规则
+func (f *File) DropReplace(oldPath, oldVersion string) error {
+	replaces := f.Module.Replaces[:0]
+	found := false
+	for _, rep := range f.Module.Replaces {
+		if rep.Old.Path != oldPath || rep.Old.Version != oldVersion {
+			replaces = append(replaces, rep)
+			continue
+		}
+		found = true
+		f.dropEntry(rep, true)
+	}
+	f.Module.Replaces = replaces
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrReplaceNotFound, strings.TrimSpace(oldPath+" "+oldVersion))
+	}
+	return nil
+}
+
+// AddExclude 添加一条排除规则，规则已存在时不做任何修改
+func (f *File) AddExclude(path, version string) error {
+	if path == "" || version == "" {
+		return ErrInvalidExclude
+	}
+	if HasExclude(f.Module, path, version) {
+		return nil
+	}
+
+	exc := &module.Exclude{Path: path, Version: version}
+	f.Module.Excludes = append(f.Module.Excludes, exc)
+	f.lines[exc] = f.addLine("exclude", excludeLine(exc))
+	return nil
+}
+
+// DropExclude 删除一条排除规则
+func (f *File) DropExclude(path, version string) error {
+	excludes := f.Module.Excludes[:0]
+	found := false
+	for _, exc := range f.Module.Excludes {
+		if exc.Path != path || exc.Version != version {
+			excludes = append(excludes, exc)
+			continue
+		}
+		found = true
+		f.dropEntry(exc, true)
+	}
+	f.Module.Excludes = excludes
+
+	if !found {
+		return fmt.Errorf("%w: %s %s", ErrExcludeNotFound, path, version)
+	}
+	return nil
+}
+
+// AddRetract 添加一条撤回声明，ret可以是单个版本（Version）或版本范围（VersionLow、VersionHigh）
+// 与go命令一样，撤回理由的每一行写为语句之前的一条注释；
+// 相同版本或范围的声明已存在时只更新其撤回理由，替换语句原有的注释；
+// 加入的块上有块级的撤回理由时，先将其移到块内原有的语句上，避免新的声明继承该理由
+func (f *File) AddRetract(ret *module.Retract) error {
+	if !validRetract(ret) {
+		return ErrInvalidRetract
+	}
+
+	if existing := f.findRetract(ret); existing != nil {
+		existing.Rationale = ret.Rationale
+		if line := f.Line(existing); line != nil {
+			line.Before = append(leadingBlankComments(line.Before), rationaleComments(ret.Rationale)...)
+			line.Suffix = nil
+		}
+		return nil
+	}
+
+	f.Module.Retracts = append(f.Module.Retracts, ret)
+	line := f.addLine("retract", retractLine(ret))
+	f.lines[ret] = line
+	if block := f.blockOf(line); block != nil {
+		moveBlockRationale(block, line)
+	}
+	return nil
+}
+
+// DropRetract 删除与ret版本或范围相同的撤回声明，不比较撤回理由
+func (f *File) DropRetract(ret *module.Retract) error {
+	retracts := f.Module.Retracts[:0]
+	found := false
+	for _, r := range f.Module.Retracts {
+		if !sameRetract(r, ret) {
+			retracts = append(retracts, r)
+			continue
+		}
+		found = true
+		f.dropEntry(r, true)
+	}
+	f.Module.Retracts = retracts
+
+	if !found {
+		return ErrRetractNotFound
+	}
+	return nil
+}
+
+// findRetract 查找与ret版本或范围相同的撤回声明
+func (f *File) findRetract(ret *module.Retract) *module.Retract {
+	for _, r := range f.Module.Retracts {
+		if sameRetract(r, ret) {
+			return r
+		}
+	}
+	return nil
+}
+
+// blockOf 返回包含该语句的块，语句不在块内时返回nil
+func (f *File) blockOf(line *Line) *LineBlock {
+	for _, stmt := range f.Syntax.Stmt {
+		if block, ok := stmt.(*LineBlock); ok {
+			for _, l := range block.Line {
+				if l == line {
+					return block
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// moveBlockRationale 将retract块之前的注释（块级的撤回理由）复制到块内没有自身注释的语句上，
+// 并从块上移除，使这些语句的撤回理由保持不变；added是新加入的语句，不复制
+func moveBlockRationale(block *LineBlock, added *Line) {
+	rationale := commentLines(block.Before)
+	if len(rationale) == 0 {
+		return
+	}
+	for _, line := range block.Line {
+		if line == added || len(commentLines(line.Before)) > 0 || len(line.Suffix) > 0 {
+			continue
+		}
+		line.Before = append(append([]Comment{}, line.Before...), rationale...)
+	}
+	block.Before = leadingBlankComments(block.Before)
+}
+
+// validRetract 检查撤回声明是单个版本或完整的版本范围之一
+func validRetract(ret *module.Retract) bool {
+	if ret == nil {
+		return false
+	}
+	if ret.Version != "" {
+		return ret.VersionLow == "" && ret.VersionHigh == ""
+	}
+	return ret.VersionLow != "" && ret.VersionHigh != ""
+}
+
+// sameRetract 检查两条撤回声明是否针对相同的版本或范围
+func sameRetract(a, b *module.Retract) bool {
+	return a.Version == b.Version && a.VersionLow == b.VersionLow && a.VersionHigh == b.VersionHigh
+}
+
+// dropEntry 删除模块信息中某一项对应的语句，collapse的含义见removeLine
+func (f *File) dropEntry(entry any, collapse bool) {
+	if line := f.Line(entry); line != nil {
+		f.removeLine(line, collapse)
+	}
+	delete(f.lines, entry)
+}
+
+// setLineTokens 替换语句的词法单元（不含指令关键字），保留语句的注释
+func setLineTokens(line *Line, tokens []string) {
+	if !line.InBlock {
+		tokens = append([]string{line.Token[0]}, tokens...)
+	}
+	line.Token = tokens
+	line.tokenPos = nil
+}
+
+// setIndirectComment 在语句的行尾注释中添加或删除indirect标记
+func setIndirectComment(line *Line, indirect bool) {
+	if indirect {
+		if len(line.Suffix) == 0 {
+			line.Suffix = []Comment{{Token: "// indirect", Suffix: true}}
+			return
+		}
+		text := strings.TrimSpace(strings.TrimPrefix(line.Suffix[0].Token, "//"))
+		line.Suffix[0].Token = "// indirect"
+		if text != "" {
+			line.Suffix[0].Token += "; " + text
+		}
+		return
+	}
+
+	suffix := line.Suffix[:0]
+	for _, comment := range line.Suffix {
+		text := strings.TrimSpace(indirectCommentRegexp.ReplaceAllString(comment.Token, ""))
+		text = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(text, "//"), ";"))
+		if text == "" {
+			continue
+		}
+		comment.Token = "// " + text
+		suffix = append(suffix, comment)
+	}
+	line.Suffix = suffix
+}
+
+// moveRequireLine 语句所在的require块中其他依赖都属于另一类时，将语句移到同类依赖的块中
+func (f *File) moveRequireLine(line *Line, indirect bool) {
+	for _, stmt := range f.Syntax.Stmt {
+		block, ok := stmt.(*LineBlock)
+		if !ok || len(block.Token) != 1 || block.Token[0] != "require" || !blockMatches(block, func(l *Line) bool { return l == line }) {
+			continue
+		}
+		lines := make([]*Line, 0, len(block.Line))
+		for _, l := range block.Line {
+			if l == line {
+				continue
+			}
+			if isIndirect(lineText(l)) == indirect {
+				return
+			}
+			lines = append(lines, l)
+		}
+		if len(lines) == 0 {
+			return
+		}
+		block.Line = lines
+		f.addRequireLine(line, indirect)
+		f.sortRequires()
+		return
+	}
+}
+
+// addLine 在语法树中添加一条指令语句，line的词法单元不含指令关键字
+// 优先追加到最后一个同类块中；只有同类单行指令时将最后一条转换为块；
+// 都没有时作为单行指令添加在最后一条指令之后
+func (f *File) addLine(keyword string, line *Line) *Line {
+	return f.insertLine(keyword, line, nil)
+}
+
+// addRequireLine 添加一条require语句，直接依赖和间接依赖分别放入对应的块：
+// 优先追加到最后一个包含同类依赖的require块（两类依赖混合的块对两者都适用）；
+// 其次将最后一条同类的单行require转换为块；只有另一类依赖时在最后一条require语句之后新建一个块；
+// 没有任何require时作为单行指令添加在最后一条指令之后
+func (f *File) addRequireLine(line *Line, indirect bool) *Line {
+	return f.insertLine("require", line, func(l *Line) bool {
+		return isIndirect(lineText(l)) == indirect
+	})
+}
+
+// insertLine 按照addLine的规则添加语句；match不为nil时只考虑包含匹配语句的块和匹配的单行指令，
+// 同类指令都不匹配时在最后一条同类指令之后新建一个块
+func (f *File) insertLine(keyword string, line *Line, match func(*Line) bool) *Line {
+	var lastBlock *LineBlock
+	lastLine, lastKeyword, lastStmt := -1, -1, -1
+	for i, stmt := range f.Syntax.Stmt {
+		switch x := stmt.(type) {
+		case *LineBlock:
+			if len(x.Token) == 1 && x.Token[0] == keyword {
+				if match == nil || blockMatches(x, match) {
+					lastBlock = x
+				}
+				lastKeyword = i
+			}
+			lastStmt = i
+		case *Line:
+			if len(x.Token) > 0 && x.Token[0] == keyword {
+				if match == nil || match(x) {
+					lastLine = i
+				}
+				lastKeyword = i
+			}
+			lastStmt = i
+		}
+	}
+
+	if lastBlock != nil {
+		line.InBlock = true
+		lastBlock.Line = append(lastBlock.Line, line)
+		return line
+	}
+
+	if lastLine >= 0 {
+		// 将单行指令转换为块，原有的注释移到块上；撤回声明之前的注释是撤回理由，保留在语句上
+		old := f.Syntax.Stmt[lastLine].(*Line)
+		block := &LineBlock{
+			Start: old.Start,
+			Token: []string{keyword},
+			Line:  []*Line{old, line},
+		}
+		if keyword != "retract" {
+			block.Comments = Comments{Before: old.Before, After: old.After}
+			old.Before, old.After = nil, nil
+		}
+		old.Token = old.Token[1:]
+		old.tokenPos = nil
+		old.InBlock = true
+		line.InBlock = true
+		f.Syntax.Stmt[lastLine] = block
+		return line
+	}
+
+	if lastKeyword >= 0 {
+		line.InBlock = true
+		f.insertStmt(lastKeyword, &LineBlock{Token: []string{keyword}, Line: []*Line{line}})
+		return line
+	}
+
+	line.InBlock = false
+	line.Token = append([]string{keyword}, line.Token...)
+	f.insertStmt(lastStmt, line)
+	return line
+}
+
+// insertStmt 在第i条语句之后插入一条语句，i为-1时插入到开头
+func (f *File) insertStmt(i int, stmt Expr) {
+	stmts := make([]Expr, 0, len(f.Syntax.Stmt)+1)
+	stmts = append(stmts, f.Syntax.Stmt[:i+1]...)
+	stmts = append(stmts, stmt)
+	f.Syntax.Stmt = append(stmts, f.Syntax.Stmt[i+1:]...)
+}
+
+// blockMatches 检查块中是否有满足条件的语句
+func blockMatches(block *LineBlock, match func(*Line) bool) bool {
+	for _, line := range block.Line {
+		if match(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeLine 从语法树中删除一条语句，删除后为空的块一并移除
+// collapse为true时只剩一条语句的块还原为单行指令；删除require时保留块，使文本改动最小
+func (f *File) removeLine(line *Line, collapse bool) {
+	stmts := f.Syntax.Stmt[:0]
+	for _, stmt := range f.Syntax.Stmt {
+		switch x := stmt.(type) {
+		case *Line:
+			if x == line {
+				continue
+			}
+		case *LineBlock:
+			lines := x.Line[:0]
+			for _, l := range x.Line {
+				if l != line {
+					lines = append(lines, l)
+				}
+			}
+			removed := len(lines) < len(x.Line)
+			x.Line = lines
+			switch {
+			case len(x.Line) == 0:
+				continue
+			case len(x.Line) == 1 && removed && collapse:
+				stmt = collapseBlock(x)
+			}
+		}
+		stmts = append(stmts, stmt)
+	}
+	f.Syntax.Stmt = stmts
+}
+
+// collapseBlock 将只有一条语句的块还原为单行指令，块上的注释合并到该语句
+// 语句最多保留一条行尾注释：语句原有行尾注释时，左括号后的注释移到语句之前，右括号后的注释移到语句之后
+func collapseBlock(block *LineBlock) *Line {
+	line := block.Line[0]
+	before := append(append([]Comment{}, block.Before...), commentLines(line.Before)...)
+	after := append(append([]Comment{}, line.After...), commentLines(block.RParen.Before)...)
+
+	suffix := line.Suffix
+	for _, comment := range block.LParen.Suffix {
+		if len(suffix) == 0 {
+			suffix = []Comment{comment}
+			continue
+		}
+		comment.Suffix = false
+		before = append(before, comment)
+	}
+	for _, comment := range block.RParen.Suffix {
+		if len(suffix) == 0 {
+			suffix = []Comment{comment}
+			continue
+		}
+		comment.Suffix = false
+		after = append(after, comment)
+	}
+
+	line.Before = before
+	line.Suffix = suffix
+	line.After = append(after, block.After...)
+	line.Token = append(append([]string{}, block.Token...), line.Token...)
+	line.tokenPos = nil
+	line.InBlock = false
+	return line
+}
+
+// leadingBlankComments 返回开头表示空行的注释
+func leadingBlankComments(comments []Comment) []Comment {
+	for i, comment := range comments {
+		if comment.Token != "" {
+			return comments[:i:i]
+		}
+	}
+	return comments[:len(comments):len(comments)]
+}
+
+// commentLines 去掉表示空行的注释
+func commentLines(comments []Comment) []Comment {
+	var result []Comment
+	for _, comment := range comments {
+		if comment.Token != "" {
+			result = append(result, comment)
+		}
+	}
+	return result
+}
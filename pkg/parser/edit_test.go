@@ -0,0 +1,639 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_AddRequire(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		path     string
+		version  string
+		expected string
+	}{
+		{
+			name: "append to last block",
+			content: `module github.com/example/module
+
+require (
+	// testify is used in tests
+	github.com/stretchr/testify v1.8.4
+)
+`,
+			path:    "golang.org/x/text",
+			version: "v0.12.0",
+			expected: `module github.com/example/module
+
+require (
+	// testify is used in tests
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/text v0.12.0
+)
+`,
+		},
+		{
+			name: "convert single line to block",
+			content: `module github.com/example/module
+
+// the only dependency
+require github.com/stretchr/testify v1.8.4 // tests
+`,
+			path:    "golang.org/x/text",
+			version: "v0.12.0",
+			expected: `module github.com/example/module
+
+// the only dependency
+require (
+	github.com/stretchr/testify v1.8.4 // tests
+	golang.org/x/text v0.12.0
+)
+`,
+		},
+		{
+			name: "add first require after last directive",
+			content: `module github.com/example/module
+
+go 1.21
+
+// trailing comment
+`,
+			path:    "golang.org/x/text",
+			version: "v0.12.0",
+			expected: `module github.com/example/module
+
+go 1.21
+
+require golang.org/x/text v0.12.0
+
+// trailing comment
+`,
+		},
+		{
+			name: "append to direct block before indirect block",
+			content: `module github.com/example/module
+
+go 1.21
+
+require (
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/text v0.12.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+`,
+			path:    "example.com/e",
+			version: "v1.0.0",
+			expected: `module github.com/example/module
+
+go 1.21
+
+require (
+	example.com/e v1.0.0
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/text v0.12.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+`,
+		},
+		{
+			name: "create direct block after indirect block",
+			content: `module github.com/example/module
+
+go 1.21
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+exclude golang.org/x/text v0.11.0
+`,
+			path:    "example.com/e",
+			version: "v1.0.0",
+			expected: `module github.com/example/module
+
+go 1.21
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+require example.com/e v1.0.0
+
+exclude golang.org/x/text v0.11.0
+`,
+		},
+		{
+			name: "append to mixed block",
+			content: `module github.com/example/module
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/stretchr/testify v1.8.4
+)
+`,
+			path:    "example.com/e",
+			version: "v1.0.0",
+			expected: `module github.com/example/module
+
+require (
+	example.com/e v1.0.0
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/stretchr/testify v1.8.4
+)
+`,
+		},
+		{
+			name: "existing require updates version",
+			content: `module github.com/example/module
+
+require golang.org/x/text v0.11.0 // indirect
+`,
+			path:    "golang.org/x/text",
+			version: "v0.12.0",
+			expected: `module github.com/example/module
+
+require golang.org/x/text v0.12.0 // indirect
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseSyntaxFromString(tt.content)
+			require.NoError(t, err)
+
+			require.NoError(t, f.AddRequire(tt.path, tt.version))
+			assert.Equal(t, tt.expected, string(f.Format()))
+
+			req := GetRequire(f.Module, tt.path)
+			require.NotNil(t, req)
+			assert.Equal(t, tt.version, req.Version)
+			assert.NotNil(t, f.Line(req))
+
+			// 格式化结果重新解析后得到相同的依赖，格式化时块内语句已排序
+			reparsed, err := ParseSyntaxFromString(tt.expected)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, reparsed.Module.Requires, f.Module.Requires)
+		})
+	}
+}
+
+func TestFile_AddRequire_Invalid(t *testing.T) {
+	f, err := ParseSyntaxFromString("module github.com/example/module\n")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, f.AddRequire("", "v1.0.0"), ErrInvalidRequire)
+	assert.ErrorIs(t, f.AddRequire("golang.org/x/text", ""), ErrInvalidRequire)
+	assert.Empty(t, f.Module.Requires)
+}
+
+func TestFile_SetRequireVersion(t *testing.T) {
+	f, err := ParseSyntaxFromString(`module github.com/example/module
+
+require (
+	github.com/stretchr/testify v1.8.4 // tests only
+	golang.org/x/text v0.12.0
+)
+`)
+	require.NoError(t, err)
+	req := GetRequire(f.Module, "github.com/stretchr/testify")
+
+	require.NoError(t, f.SetRequireVersion("github.com/stretchr/testify", "v1.9.0"))
+	assert.Equal(t, "v1.9.0", req.Version)
+	assert.Equal(t, `module github.com/example/module
+
+require (
+	github.com/stretchr/testify v1.9.0 // tests only
+	golang.org/x/text v0.12.0
+)
+`, string(f.Format()))
+
+	assert.ErrorIs(t, f.SetRequireVersion("github.com/missing/dep", "v1.0.0"), ErrRequireNotFound)
+}
+
+func TestFile_DropRequire(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		path     string
+		expected string
+	}{
+		{
+			name: "drop line from block",
+			content: `module github.com/example/module
+
+require (
+	github.com/stretchr/testify v1.8.4
+	// text handling
+	golang.org/x/text v0.12.0
+	golang.org/x/tools v0.13.0
+)
+`,
+			path: "golang.org/x/text",
+			expected: `module github.com/example/module
+
+require (
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/tools v0.13.0
+)
+`,
+		},
+		{
+			name: "keep block with one remaining line",
+			content: `module github.com/example/module
+
+// dependencies
+require ( // pinned
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/text v0.12.0
+	// keep sorted
+)
+`,
+			path: "golang.org/x/text",
+			expected: `module github.com/example/module
+
+// dependencies
+require ( // pinned
+	github.com/stretchr/testify v1.8.4
+// keep sorted
+)
+`,
+		},
+		{
+			name: "drop empty block",
+			content: `module github.com/example/module
+
+require (
+	golang.org/x/text v0.12.0
+)
+
+go 1.21
+`,
+			path: "golang.org/x/text",
+			expected: `module github.com/example/module
+
+go 1.21
+`,
+		},
+		{
+			name: "drop single line and duplicates",
+			content: `module github.com/example/module
+
+require golang.org/x/text v0.11.0
+
+require golang.org/x/text v0.12.0
+`,
+			path: "golang.org/x/text",
+			expected: `module github.com/example/module
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseSyntaxFromString(tt.content)
+			require.NoError(t, err)
+
+			require.NoError(t, f.DropRequire(tt.path))
+			assert.Equal(t, tt.expected, string(f.Format()))
+			assert.False(t, HasRequire(f.Module, tt.path))
+		})
+	}
+
+	f, err := ParseSyntaxFromString("module github.com/example/module\n")
+	require.NoError(t, err)
+	assert.ErrorIs(t, f.DropRequire("golang.org/x/text"), ErrRequireNotFound)
+}
+
+func TestFile_SetIndirect(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		indirect bool
+		expected string
+	}{
+		{
+			name:     "add indirect comment",
+			line:     "golang.org/x/text v0.12.0",
+			indirect: true,
+			expected: "golang.org/x/text v0.12.0 // indirect",
+		},
+		{
+			name:     "prepend to existing comment",
+			line:     "golang.org/x/text v0.12.0 // pinned",
+			indirect: true,
+			expected: "golang.org/x/text v0.12.0 // indirect; pinned",
+		},
+		{
+			name:     "remove indirect comment",
+			line:     "golang.org/x/text v0.12.0 // indirect",
+			indirect: false,
+			expected: "golang.org/x/text v0.12.0",
+		},
+		{
+			name:     "keep remaining comment",
+			line:     "golang.org/x/text v0.12.0 // indirect; pinned",
+			indirect: false,
+			expected: "golang.org/x/text v0.12.0 // pinned",
+		},
+		{
+			name:     "unchanged",
+			line:     "golang.org/x/text v0.12.0 // indirect",
+			indirect: true,
+			expected: "golang.org/x/text v0.12.0 // indirect",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseSyntaxFromString("module github.com/example/module\n\nrequire (\n\t" + tt.line + "\n)\n")
+			require.NoError(t, err)
+
+			require.NoError(t, f.SetIndirect("golang.org/x/text", tt.indirect))
+			// 只有一条语句的块格式化为单行指令
+			assert.Equal(t, "module github.com/example/module\n\nrequire "+tt.expected+"\n", string(f.Format()))
+			assert.Equal(t, tt.indirect, f.Module.Requires[0].Indirect)
+
+			reparsed, err := ParseSyntaxFromString(string(f.Format()))
+			require.NoError(t, err)
+			assert.Equal(t, tt.indirect, reparsed.Module.Requires[0].Indirect)
+		})
+	}
+
+	f, err := ParseSyntaxFromString("module github.com/example/module\n")
+	require.NoError(t, err)
+	assert.ErrorIs(t, f.SetIndirect("golang.org/x/text", true), ErrRequireNotFound)
+}
+
+func TestFile_SetIndirect_MovesBetweenBlocks(t *testing.T) {
+	const content = `module github.com/example/module
+
+go %s
+
+require (
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/text v0.12.0
+)
+
+require (
+	// spew is pulled in by testify
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+`
+
+	f, err := ParseSyntaxFromString(fmt.Sprintf(content, "1.21"))
+	require.NoError(t, err)
+
+	// 新添加的依赖标记为间接依赖后移到间接依赖块
+	require.NoError(t, f.AddRequire("example.com/e", "v1.0.0"))
+	require.NoError(t, f.SetIndirect("example.com/e", true))
+	// 已有的依赖改为直接依赖后移到直接依赖块，注释随语句移动
+	require.NoError(t, f.SetIndirect("github.com/davecgh/go-spew", false))
+
+	assert.Equal(t, `module github.com/example/module
+
+go 1.21
+
+require (
+	// spew is pulled in by testify
+	github.com/davecgh/go-spew v1.1.1
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/text v0.12.0
+)
+
+require (
+	example.com/e v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+`, string(f.Format()))
+
+	// go 1.17之前的文件不区分两类依赖的块，只修改注释
+	f, err = ParseSyntaxFromString(fmt.Sprintf(content, "1.16"))
+	require.NoError(t, err)
+	require.NoError(t, f.SetIndirect("golang.org/x/text", true))
+	assert.Equal(t, strings.Replace(fmt.Sprintf(content, "1.16"),
+		"golang.org/x/text v0.12.0\n", "golang.org/x/text v0.12.0 // indirect\n", 1), string(f.Format()))
+}
+
+func TestFile_AddReplace(t *testing.T) {
+	f, err := ParseSyntaxFromString(`module github.com/example/module
+
+require github.com/example/dep v1.0.0
+
+replace github.com/example/dep => ../dep
+`)
+	require.NoError(t, err)
+
+	// 新的替换规则与已有的单行replace合并为块
+	require.NoError(t, f.AddReplace("github.com/example/other", "v1.2.0", "github.com/fork/other", "v1.2.1"))
+	assert.Equal(t, `module github.com/example/module
+
+require github.com/example/dep v1.0.0
+
+replace (
+	github.com/example/dep => ../dep
+	github.com/example/other v1.2.0 => github.com/fork/other v1.2.1
+)
+`, string(f.Format()))
+
+	// 已有的替换规则只改写替换目标
+	require.NoError(t, f.AddReplace("github.com/example/dep", "", "github.com/fork/dep", "v1.0.1"))
+	rep := GetReplace(f.Module, "github.com/example/dep")
+	require.NotNil(t, rep)
+	assert.Equal(t, "github.com/fork/dep", rep.New.Path)
+	assert.Equal(t, module.ReplaceKindModule, rep.Kind())
+	assert.Len(t, f.Module.Replaces, 2)
+
+	// 删除后只剩一条规则的块还原为单行指令
+	require.NoError(t, f.DropReplace("github.com/example/other", "v1.2.0"))
+	assert.Equal(t, `module github.com/example/module
+
+require github.com/example/dep v1.0.0
+
+replace github.com/example/dep => github.com/fork/dep v1.0.1
+`, string(f.Format()))
+
+	assert.ErrorIs(t, f.DropReplace("github.com/example/other", "v1.2.0"), ErrReplaceNotFound)
+	assert.ErrorIs(t, f.AddReplace("github.com/example/dep", "", "../dep", "v1.0.0"), ErrInvalidReplace)
+	assert.ErrorIs(t, f.AddReplace("github.com/example/dep", "", "github.com/fork/dep", ""), ErrInvalidReplace)
+	assert.ErrorIs(t, f.AddReplace("", "", "../dep", ""), ErrInvalidReplace)
+}
+
+func TestFile_DropReplace_CollapseComments(t *testing.T) {
+	f, err := ParseSyntaxFromString(`module github.com/example/module
+
+// forks
+replace ( // temporary
+	github.com/example/a => ../a // keep
+	github.com/example/b => ../b
+) // until upstream merges
+`)
+	require.NoError(t, err)
+
+	// 块还原为单行指令时语句只保留一条行尾注释，其余注释移到语句前后
+	require.NoError(t, f.DropReplace("github.com/example/b", ""))
+	assert.Equal(t, `module github.com/example/module
+
+// forks
+// temporary
+replace github.com/example/a => ../a // keep
+// until upstream merges
+`, string(f.Format()))
+
+	f, err = ParseSyntaxFromString(`module github.com/example/module
+
+replace ( // temporary
+	github.com/example/a => ../a
+	github.com/example/b => ../b
+)
+`)
+	require.NoError(t, err)
+	require.NoError(t, f.DropReplace("github.com/example/b", ""))
+	assert.Equal(t, "module github.com/example/module\n\nreplace github.com/example/a => ../a // temporary\n", string(f.Format()))
+}
+
+func TestFile_AddRetract_KeepsLeadingRationale(t *testing.T) {
+	f, err := ParseSyntaxFromString("module github.com/example/module\n\n// broken build\nretract v1.0.0\n")
+	require.NoError(t, err)
+	assert.Equal(t, "broken build", f.Module.Retracts[0].Rationale)
+
+	require.NoError(t, f.AddRetract(&module.Retract{Version: "v1.0.1", Rationale: "same bug"}))
+	assert.Equal(t, `module github.com/example/module
+
+retract (
+	// same bug
+	v1.0.1
+	// broken build
+	v1.0.0
+)
+`, string(f.Format()))
+
+	// 格式化时retract块按版本从高到低排序
+	reparsed, err := ParseSyntaxFromString(string(f.Format()))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, f.Module.Retracts, reparsed.Module.Retracts)
+}
+
+func TestFile_AddRetract_BlockRationale(t *testing.T) {
+	f, err := ParseSyntaxFromString(`module github.com/example/module
+
+// broken build
+retract (
+	v1.0.0
+	// own reason
+	v1.1.0
+)
+`)
+	require.NoError(t, err)
+	assert.Equal(t, "broken build", f.Module.Retracts[0].Rationale)
+
+	// 新的声明没有撤回理由，不能继承块上的注释
+	require.NoError(t, f.AddRetract(&module.Retract{Version: "v1.2.0"}))
+	assert.Equal(t, `module github.com/example/module
+
+retract (
+	v1.2.0
+	// own reason
+	v1.1.0
+	// broken build
+	v1.0.0
+)
+`, string(f.Format()))
+
+	reparsed, err := ParseSyntaxFromString(string(f.Format()))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, f.Module.Retracts, reparsed.Module.Retracts)
+	assert.ElementsMatch(t, []*module.Retract{
+		{Version: "v1.0.0", Rationale: "broken build"},
+		{Version: "v1.1.0", Rationale: "own reason"},
+		{Version: "v1.2.0"},
+	}, reparsed.Module.Retracts)
+}
+
+func TestFile_AddDropExclude(t *testing.T) {
+	f, err := ParseSyntaxFromString("module github.com/example/module\n\ngo 1.21\n")
+	require.NoError(t, err)
+
+	require.NoError(t, f.AddExclude("golang.org/x/net", "v0.1.0"))
+	require.NoError(t, f.AddExclude("golang.org/x/net", "v0.1.0"))
+	assert.Equal(t, "module github.com/example/module\n\ngo 1.21\n\nexclude golang.org/x/net v0.1.0\n", string(f.Format()))
+
+	require.NoError(t, f.AddExclude("golang.org/x/net", "v0.2.0"))
+	assert.Equal(t, "module github.com/example/module\n\ngo 1.21\n\nexclude (\n\tgolang.org/x/net v0.1.0\n\tgolang.org/x/net v0.2.0\n)\n", string(f.Format()))
+	assert.Len(t, f.Module.Excludes, 2)
+
+	require.NoError(t, f.DropExclude("golang.org/x/net", "v0.1.0"))
+	require.NoError(t, f.DropExclude("golang.org/x/net", "v0.2.0"))
+	assert.Equal(t, "module github.com/example/module\n\ngo 1.21\n", string(f.Format()))
+	assert.Empty(t, f.Module.Excludes)
+
+	assert.ErrorIs(t, f.DropExclude("golang.org/x/net", "v0.2.0"), ErrExcludeNotFound)
+	assert.ErrorIs(t, f.AddExclude("golang.org/x/net", ""), ErrInvalidExclude)
+}
+
+func TestFile_AddDropRetract(t *testing.T) {
+	f, err := ParseSyntaxFromString("module github.com/example/module\n\nretract v1.0.0 // broken build\n")
+	require.NoError(t, err)
+
+	// 多行撤回理由与go命令一样逐行写为语句之前的注释，格式化后重新解析得到相同的理由
+	require.NoError(t, f.AddRetract(&module.Retract{
+		VersionLow:  "v1.1.0",
+		VersionHigh: "v1.1.5",
+		Rationale:   "data corruption\nin the cache",
+	}))
+	assert.Equal(t, `module github.com/example/module
+
+retract (
+	// data corruption
+	// in the cache
+	[v1.1.0, v1.1.5]
+	v1.0.0 // broken build
+)
+`, string(f.Format()))
+
+	reparsed, err := ParseSyntaxFromString(string(f.Format()))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, f.Module.Retracts, reparsed.Module.Retracts)
+	assert.Equal(t, "data corruption\nin the cache", reparsed.Module.Retracts[0].Rationale)
+
+	// 已有的撤回声明只更新撤回理由
+	require.NoError(t, f.AddRetract(&module.Retract{Version: "v1.0.0", Rationale: "published by mistake"}))
+	require.NoError(t, f.AddRetract(&module.Retract{VersionLow: "v1.1.0", VersionHigh: "v1.1.5"}))
+	assert.Equal(t, `module github.com/example/module
+
+retract (
+	[v1.1.0, v1.1.5]
+	// published by mistake
+	v1.0.0
+)
+`, string(f.Format()))
+
+	reparsed, err = ParseSyntaxFromString(string(f.Format()))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, reparsed.Module.Retracts, f.Module.Retracts)
+
+	require.NoError(t, f.DropRetract(&module.Retract{Version: "v1.0.0"}))
+	assert.Equal(t, "module github.com/example/module\n\nretract [v1.1.0, v1.1.5]\n", string(f.Format()))
+
+	assert.ErrorIs(t, f.DropRetract(&module.Retract{Version: "v1.0.0"}), ErrRetractNotFound)
+	assert.ErrorIs(t, f.AddRetract(&module.Retract{VersionLow: "v1.0.0"}), ErrInvalidRetract)
+	assert.ErrorIs(t, f.AddRetract(&module.Retract{Version: "v1.0.0", VersionHigh: "v1.0.1"}), ErrInvalidRetract)
+	assert.ErrorIs(t, f.AddRetract(nil), ErrInvalidRetract)
+}
@@ -2,19 +2,142 @@ package parser
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 var (
+	// ErrInvalidQuotedString 表示无法解析带引号的字符串字面量
+	ErrInvalidQuotedString = errors.New("invalid quoted string")
+	// ErrUnrecognizedLine 表示无法识别的语句
+	ErrUnrecognizedLine = errors.New("unrecognized line format")
+	// ErrUnknownBlock 表示无法识别的块类型
+	ErrUnknownBlock = errors.New("unknown block type")
+	// ErrUnclosedBlock 表示块在文件结束前没有闭合
+	ErrUnclosedBlock = errors.New("unclosed block")
 	// ErrInvalidModuleDeclaration 表示无法解析module声明
 	ErrInvalidModuleDeclaration = errors.New("invalid module declaration")
 	// ErrInvalidGoVersion 表示无法解析go版本声明
 	ErrInvalidGoVersion = errors.New("invalid go version declaration")
+	// ErrInvalidToolchain 表示无法解析toolchain声明
+	ErrInvalidToolchain = errors.New("invalid toolchain declaration")
+	// ErrInvalidGodebug 表示无法解析godebug声明
+	ErrInvalidGodebug = errors.New("invalid godebug declaration")
 	// ErrInvalidRequire 表示无法解析require声明
 	ErrInvalidRequire = errors.New("invalid require declaration")
+	// ErrInvalidTool 表示无法解析tool声明
+	ErrInvalidTool = errors.New("invalid tool declaration")
 	// ErrInvalidReplace 表示无法解析replace声明
 	ErrInvalidReplace = errors.New("invalid replace declaration")
 	// ErrInvalidExclude 表示无法解析exclude声明
 	ErrInvalidExclude = errors.New("invalid exclude declaration")
 	// ErrInvalidRetract 表示无法解析retract声明
 	ErrInvalidRetract = errors.New("invalid retract declaration")
+	// ErrRequireNotFound 表示要修改的require声明不存在
+	ErrRequireNotFound = errors.New("require not found")
+	// ErrReplaceNotFound 表示要修改的replace声明不存在
+	ErrReplaceNotFound = errors.New("replace not found")
+	// ErrExcludeNotFound 表示要修改的exclude声明不存在
+	ErrExcludeNotFound = errors.New("exclude not found")
+	// ErrRetractNotFound 表示要修改的retract声明不存在
+	ErrRetractNotFound = errors.New("retract not found")
 )
+
+// directiveErrors 记录各指令对应的哨兵错误，用于报告关键字正确但参数无法解析的语句
+var directiveErrors = map[string]error{
+	"module":    ErrInvalidModuleDeclaration,
+	"go":        ErrInvalidGoVersion,
+	"toolchain": ErrInvalidToolchain,
+	"godebug":   ErrInvalidGodebug,
+	"require":   ErrInvalidRequire,
+	"tool":      ErrInvalidTool,
+	"replace":   ErrInvalidReplace,
+	"exclude":   ErrInvalidExclude,
+	"retract":   ErrInvalidRetract,
+}
+
+// ParseError 表示解析go.mod文件时遇到的错误，可以通过errors.As获取
+type ParseError struct {
+	// File 文件路径，从io.Reader或字符串解析时为空
+	File string
+
+	// Line 出错的行号，从1开始
+	Line int
+
+	// Column 出错的列号（按字节计算），从1开始
+	Column int
+
+	// Directive 出错语句所属的指令，例如 require，无法确定时为空
+	Directive string
+
+	// Text 导致错误的原始文本
+	Text string
+
+	// Err 底层错误，通常是本包定义的哨兵错误，可以通过errors.Is判断
+	Err error
+}
+
+// Error 实现error接口
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	if e.File != "" {
+		fmt.Fprintf(&b, "%s: ", e.File)
+	}
+	fmt.Fprintf(&b, "line %d", e.Line)
+	if e.Column > 0 {
+		fmt.Fprintf(&b, ", column %d", e.Column)
+	}
+	fmt.Fprintf(&b, ": %v", e.Err)
+	if e.Text != "" {
+		fmt.Fprintf(&b, ": %s", e.Text)
+	}
+	return b.String()
+}
+
+// Unwrap 返回底层错误，便于使用errors.Is判断
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorList 表示解析过程中收集到的多个错误，按出现顺序排列
+type ErrorList []*ParseError
+
+// Error 实现error接口，返回第一个错误及剩余错误的数量
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	default:
+		return fmt.Sprintf("%s (and %d more errors)", l[0].Error(), len(l)-1)
+	}
+}
+
+// Unwrap 返回所有错误，便于使用errors.Is和errors.As判断
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, err := range l {
+		errs[i] = err
+	}
+	return errs
+}
+
+// tokenError 表示由某个具体词法单元导致的错误，用于定位错误所在的列
+type tokenError struct {
+	// token 出错的原始文本
+	token string
+
+	// err 底层错误
+	err error
+}
+
+// Error 实现error接口
+func (e *tokenError) Error() string {
+	return fmt.Sprintf("%v: %s", e.err, e.token)
+}
+
+// Unwrap 返回底层错误，便于使用errors.Is判断
+func (e *tokenError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseError_Fields(t *testing.T) {
+	tests := []struct {
+		name            string
+		content         string
+		expectLine      int
+		expectColumn    int
+		expectDirective string
+		expectText      string
+		expectErr       error
+	}{
+		{
+			name:            "unrecognized line",
+			content:         "module github.com/example/module\n\ninvalid single line\n",
+			expectLine:      3,
+			expectColumn:    1,
+			expectDirective: "",
+			expectText:      "invalid single line",
+			expectErr:       ErrUnrecognizedLine,
+		},
+		{
+			name:            "malformed single-line require",
+			content:         "module github.com/example/module\n\nrequire github.com/example/pkg\n",
+			expectLine:      3,
+			expectColumn:    1,
+			expectDirective: "require",
+			expectText:      "require github.com/example/pkg",
+			expectErr:       ErrInvalidRequire,
+		},
+		{
+			name:            "malformed block line",
+			content:         "module github.com/example/module\n\nrequire (\n\tgithub.com/example/pkg\n)\n",
+			expectLine:      4,
+			expectColumn:    2,
+			expectDirective: "require",
+			expectText:      "github.com/example/pkg",
+			expectErr:       ErrInvalidRequire,
+		},
+		{
+			name:            "malformed quoted string",
+			content:         "module github.com/example/module\n\nrequire (\n\tgithub.com/pkg/errors \"v0.9.1\n)\n",
+			expectLine:      4,
+			expectColumn:    24,
+			expectDirective: "require",
+			expectText:      `"v0.9.1`,
+			expectErr:       ErrInvalidQuotedString,
+		},
+		{
+			name:            "unterminated quoted path",
+			content:         "module github.com/example/module\n\nrequire \"example.com/x v1.0.0\n",
+			expectLine:      3,
+			expectColumn:    9,
+			expectDirective: "require",
+			expectText:      `"example.com/x v1.0.0`,
+			expectErr:       ErrInvalidQuotedString,
+		},
+		{
+			name:            "unterminated quoted path in block",
+			content:         "module github.com/example/module\n\nrequire (\n\t\"example.com/x v1.0.0\n)\n",
+			expectLine:      4,
+			expectColumn:    2,
+			expectDirective: "require",
+			expectText:      `"example.com/x v1.0.0`,
+			expectErr:       ErrInvalidQuotedString,
+		},
+		{
+			name:            "unknown block",
+			content:         "module github.com/example/module\n\nmodule (\n\tgithub.com/example/other\n)\n",
+			expectLine:      3,
+			expectColumn:    1,
+			expectDirective: "",
+			expectText:      "module",
+			expectErr:       ErrUnknownBlock,
+		},
+		{
+			name:            "unclosed block",
+			content:         "module github.com/example/module\n\nexclude (\n\tgithub.com/bad/pkg v1.0.0\n",
+			expectLine:      3,
+			expectColumn:    1,
+			expectDirective: "exclude",
+			expectText:      "exclude (",
+			expectErr:       ErrUnclosedBlock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFromString(tt.content)
+			require.Error(t, err)
+
+			var parseErr *ParseError
+			require.True(t, errors.As(err, &parseErr))
+			assert.Equal(t, "", parseErr.File)
+			assert.Equal(t, tt.expectLine, parseErr.Line)
+			assert.Equal(t, tt.expectColumn, parseErr.Column)
+			assert.Equal(t, tt.expectDirective, parseErr.Directive)
+			assert.Equal(t, tt.expectText, parseErr.Text)
+			assert.ErrorIs(t, err, tt.expectErr)
+		})
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	err := &ParseError{
+		File:      "go.mod",
+		Line:      4,
+		Column:    2,
+		Directive: "require",
+		Text:      "github.com/example/pkg",
+		Err:       ErrInvalidRequire,
+	}
+	assert.Equal(t, "go.mod: line 4, column 2: invalid require declaration: github.com/example/pkg", err.Error())
+
+	err = &ParseError{Line: 3, Err: ErrUnrecognizedLine}
+	assert.Equal(t, "line 3: unrecognized line format", err.Error())
+}
+
+func TestParseError_FileName(t *testing.T) {
+	tempDir := t.TempDir()
+	goModPath := filepath.Join(tempDir, "go.mod")
+	require.NoError(t, os.WriteFile(goModPath, []byte("module github.com/example/module\n\ngo\n"), 0644))
+
+	_, err := ParseFromFile(goModPath)
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, goModPath, parseErr.File)
+	assert.Equal(t, 3, parseErr.Line)
+	assert.Equal(t, "go", parseErr.Directive)
+	assert.ErrorIs(t, err, ErrInvalidGoVersion)
+}
@@ -1,17 +1,20 @@
 package parser
 
 import (
-	"strings"
-
 	"github.com/scagogogo/go-mod-parser/pkg/module"
 )
 
 // parseExcludeSingleLine 解析单行exclude语句
 func parseExcludeSingleLine(mod *module.Module, line string) (bool, error) {
 	if matches := singleExcludeRegexp.FindStringSubmatch(line); len(matches) == 3 {
+		values, err := parseStrings(matches[1], matches[2])
+		if err != nil {
+			return false, err
+		}
+
 		mod.Excludes = append(mod.Excludes, &module.Exclude{
-			Path:    matches[1],
-			Version: matches[2],
+			Path:    values[0],
+			Version: values[1],
 		})
 		return true, nil
 	}
@@ -20,13 +23,18 @@ func parseExcludeSingleLine(mod *module.Module, line string) (bool, error) {
 
 // parseExcludeBlockLine 解析exclude块内的语句
 func parseExcludeBlockLine(mod *module.Module, line string) error {
-	parts := strings.Fields(line)
+	parts := withoutComment(splitFields(line))
 	if len(parts) < 2 {
 		return ErrInvalidExclude
 	}
+	values, err := parseStrings(parts[0], parts[1])
+	if err != nil {
+		return err
+	}
+
 	mod.Excludes = append(mod.Excludes, &module.Exclude{
-		Path:    parts[0],
-		Version: parts[1],
+		Path:    values[0],
+		Version: values[1],
 	})
 	return nil
 }
@@ -0,0 +1,288 @@
+package parser
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+)
+
+// File 表示解析后的go.mod文件，同时保存保留注释的语法树以及由语法树得到的模块信息
+type File struct {
+	// Module 由语法树得到的模块信息
+	Module *module.Module
+
+	// Syntax 保留注释和位置信息的具体语法树
+	Syntax *FileSyntax
+
+	// lines 记录模块信息中每一项对应的语法行
+	lines map[any]*Line
+}
+
+// Line 返回模块信息中某一项对应的语法行，未找到时返回nil
+// entry可以是Module中各切片的元素（例如*module.Require），
+// 也可以是指令关键字 "module"、"go" 或 "toolchain"
+func (f *File) Line(entry any) *Line {
+	return f.lines[entry]
+}
+
+// ParseSyntax 按照指定选项从io.Reader解析go.mod文件，同时返回语法树和模块信息
+// 错误处理方式与ParseWithOptions相同
+func ParseSyntax(r io.Reader, opts Options) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return parseFile(data, opts)
+}
+
+// ParseSyntaxFromString 从字符串解析go.mod文件，同时返回语法树和模块信息
+func ParseSyntaxFromString(s string) (*File, error) {
+	return parseFile([]byte(s), Options{})
+}
+
+// ParseSyntaxFromFile 从文件解析go.mod文件，同时返回语法树和模块信息
+// 本地目录替换的目标会相对于go.mod所在目录解析，结果记录在ReplaceItem.Dir中
+func ParseSyntaxFromFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFile(data, Options{FileName: path})
+}
+
+// parseFile 解析语法树并由其得到模块信息
+func parseFile(data []byte, opts Options) (*File, error) {
+	syntax, errs := parseSyntax(opts.FileName, data)
+
+	f := &File{Syntax: syntax}
+	errs = append(errs, f.build(opts.Recover)...)
+	if opts.FileName != "" && f.Module != nil {
+		resolveLocalReplaces(f.Module, fileDir(opts.FileName))
+	}
+	if opts.Validate && (len(errs) == 0 || opts.Recover) {
+		errs = append(errs, f.validate()...)
+	}
+	for _, err := range errs {
+		err.File = opts.FileName
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Line < errs[j].Line
+	})
+
+	if len(errs) > 0 {
+		if !opts.Recover {
+			return nil, errs[0]
+		}
+		return f, errs
+	}
+	return f, nil
+}
+
+// build 遍历语法树得到模块信息，keepGoing为false时在第一个错误处停止
+func (f *File) build(keepGoing bool) ErrorList {
+	mod := &module.Module{
+		Godebugs: make([]*module.Godebug, 0),
+		Requires: make([]*module.Require, 0),
+		Tools:    make([]*module.Tool, 0),
+		Replaces: make([]*module.Replace, 0),
+		Excludes: make([]*module.Exclude, 0),
+		Retracts: make([]*module.Retract, 0),
+	}
+	f.Module = mod
+	f.lines = make(map[any]*Line)
+
+	var errs ErrorList
+	for _, stmt := range f.Syntax.Stmt {
+		switch x := stmt.(type) {
+		case *Line:
+			text := lineText(x)
+			before := snapshot(mod)
+			err := checkQuotedTokens(x)
+			if err == nil {
+				err = handleSingleLine(mod, text)
+			}
+			if err != nil {
+				errs = append(errs, lineParseError(x, directiveOf(text), err))
+				if !keepGoing {
+					return errs
+				}
+				continue
+			}
+			f.record(mod, before, x)
+			setRationales(mod, before, nil, x)
+
+			// module声明可能通过注释标记为弃用
+			if moduleRegexp.MatchString(text) {
+				mod.Deprecated = parseDeprecation(commentTokens(x.Before), text)
+			}
+
+		case *LineBlock:
+			blockType := strings.Join(x.Token, " ")
+
+			// 未知类型的块整体报告一次错误，恢复模式下跳过块内的所有语句
+			if !isBlockDirective(blockType) {
+				errs = append(errs, &ParseError{
+					Line:   x.Start.Line,
+					Column: x.Start.Column,
+					Text:   blockType,
+					Err:    ErrUnknownBlock,
+				})
+				if !keepGoing {
+					return errs
+				}
+				continue
+			}
+
+			for _, line := range x.Line {
+				before := snapshot(mod)
+				err := checkQuotedTokens(line)
+				if err == nil {
+					err = handleBlockLine(mod, blockType, lineText(line))
+				}
+				if err != nil {
+					errs = append(errs, lineParseError(line, blockType, err))
+					if !keepGoing {
+						return errs
+					}
+					continue
+				}
+				f.record(mod, before, line)
+				setRationales(mod, before, x, line)
+			}
+		}
+	}
+	return errs
+}
+
+// entryCounts 记录模块信息中各切片的长度，用于找出某条语句新增的项
+type entryCounts struct {
+	godebugs, requires, tools, replaces, excludes, retracts int
+}
+
+// snapshot 记录模块信息中各切片当前的长度
+func snapshot(mod *module.Module) entryCounts {
+	return entryCounts{
+		godebugs: len(mod.Godebugs),
+		requires: len(mod.Requires),
+		tools:    len(mod.Tools),
+		replaces: len(mod.Replaces),
+		excludes: len(mod.Excludes),
+		retracts: len(mod.Retracts),
+	}
+}
+
+// record 将语句新增的项与该语句关联起来
+func (f *File) record(mod *module.Module, before entryCounts, line *Line) {
+	for _, v := range mod.Godebugs[before.godebugs:] {
+		f.lines[v] = line
+	}
+	for _, v := range mod.Requires[before.requires:] {
+		f.lines[v] = line
+	}
+	for _, v := range mod.Tools[before.tools:] {
+		f.lines[v] = line
+	}
+	for _, v := range mod.Replaces[before.replaces:] {
+		f.lines[v] = line
+	}
+	for _, v := range mod.Excludes[before.excludes:] {
+		f.lines[v] = line
+	}
+	for _, v := range mod.Retracts[before.retracts:] {
+		f.lines[v] = line
+	}
+
+	if !line.InBlock && len(line.Token) > 0 {
+		switch line.Token[0] {
+		case "module", "go", "toolchain":
+			f.lines[line.Token[0]] = line
+		}
+	}
+}
+
+// setRationales 由语句的注释得到新增撤回声明的理由
+func setRationales(mod *module.Module, before entryCounts, block *LineBlock, line *Line) {
+	for _, ret := range mod.Retracts[before.retracts:] {
+		ret.Rationale = directiveComment(block, line)
+	}
+}
+
+// directiveComment 返回语句的说明注释，与go命令读取撤回理由的方式相同：
+// 语句之前的注释和行尾注释逐条去掉 // 后以换行连接；块内的语句没有注释时使用块之前的注释
+func directiveComment(block *LineBlock, line *Line) string {
+	comments := append(append([]Comment{}, line.Before...), line.Suffix...)
+	if block != nil && len(commentLines(comments)) == 0 {
+		comments = block.Before
+	}
+
+	var lines []string
+	for _, comment := range comments {
+		if strings.HasPrefix(comment.Token, "//") {
+			lines = append(lines, strings.TrimSpace(strings.TrimPrefix(comment.Token, "//")))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lineText 将语句还原为一行文本（包含行尾注释），交给各指令的处理函数解析
+func lineText(line *Line) string {
+	text := joinTokens(line.Token)
+	for _, comment := range line.Suffix {
+		text += " " + comment.Token
+	}
+	return text
+}
+
+// commentTokens 返回注释原文列表，忽略表示空行的注释
+func commentTokens(comments []Comment) []string {
+	tokens := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		if comment.Token != "" {
+			tokens = append(tokens, comment.Token)
+		}
+	}
+	return tokens
+}
+
+// checkQuotedTokens 检查语句中带引号的词法单元是否都是合法的字符串字面量
+// 直接检查词法分析得到的词法单元，未闭合的字符串报告在其起始位置，而不是被空白切分后的片段
+func checkQuotedTokens(line *Line) error {
+	for _, token := range line.Token {
+		if _, err := parseString(token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lineParseError 根据出错的语句构造ParseError
+// 错误由具体词法单元导致时定位到该词法单元，否则定位到语句的第一个词法单元
+func lineParseError(line *Line, directive string, err error) *ParseError {
+	parseErr := &ParseError{
+		Line:      line.Start.Line,
+		Column:    line.Start.Column,
+		Directive: directive,
+		Text:      lineText(line),
+		Err:       err,
+	}
+
+	var tokErr *tokenError
+	if errors.As(err, &tokErr) {
+		parseErr.Text = tokErr.token
+		parseErr.Err = tokErr.err
+		for i, token := range line.Token {
+			if token == tokErr.token {
+				pos := line.TokenPosition(i)
+				parseErr.Line = pos.Line
+				parseErr.Column = pos.Column
+				break
+			}
+		}
+	}
+	return parseErr
+}
@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSyntaxFromString(t *testing.T) {
+	content := `// Deprecated: use v2
+module github.com/example/module
+
+go 1.21 // language version
+
+require github.com/stretchr/testify v1.8.4
+
+require (
+	// errors helpers
+	github.com/pkg/errors v0.9.1 // indirect
+)
+
+exclude github.com/bad/pkg v1.0.0 // broken
+
+retract [v1.0.0, v1.0.5] // bad releases
+`
+	f, err := ParseSyntaxFromString(content)
+	require.NoError(t, err)
+	require.NotNil(t, f.Syntax)
+
+	// 模块信息由语法树得到
+	mod := f.Module
+	assert.Equal(t, "github.com/example/module", mod.Name)
+	assert.Equal(t, "use v2", mod.Deprecated)
+	assert.Equal(t, "1.21", mod.GoVersion)
+	require.Len(t, mod.Requires, 2)
+	assert.True(t, mod.Requires[1].Indirect)
+	require.Len(t, mod.Excludes, 1)
+	require.Len(t, mod.Retracts, 1)
+	assert.Equal(t, "v1.0.0", mod.Retracts[0].VersionLow)
+	assert.Equal(t, "bad releases", mod.Retracts[0].Rationale)
+
+	// 每一项都能找到对应的语法行
+	line := f.Line(mod.Requires[1])
+	require.NotNil(t, line)
+	assert.Equal(t, 10, line.Start.Line)
+	assert.Equal(t, []string{"// errors helpers"}, commentTokens(line.Before))
+
+	assert.Equal(t, 2, f.Line("module").Start.Line)
+	assert.Equal(t, 4, f.Line("go").Start.Line)
+	assert.Equal(t, 13, f.Line(mod.Excludes[0]).Start.Line)
+	assert.Equal(t, 15, f.Line(mod.Retracts[0]).Start.Line)
+	assert.Nil(t, f.Line("toolchain"))
+	assert.Nil(t, f.Line(&module.Require{}))
+}
+
+func TestParseSyntax_RetractRationale(t *testing.T) {
+	f, err := ParseSyntaxFromString(`module github.com/example/module
+
+// all published by mistake
+retract (
+	v1.0.0
+	// data corruption
+	// in the cache
+	[v1.1.0, v1.1.5]
+	v1.2.0 // broken build
+)
+
+// leaked credentials
+retract v1.3.0
+`)
+	require.NoError(t, err)
+
+	// 与go命令一样，理由来自语句之前的注释和行尾注释，块内没有注释的语句使用块之前的注释
+	rationales := make([]string, 0, len(f.Module.Retracts))
+	for _, ret := range f.Module.Retracts {
+		rationales = append(rationales, ret.Rationale)
+	}
+	assert.Equal(t, []string{
+		"all published by mistake",
+		"data corruption\nin the cache",
+		"broken build",
+		"leaked credentials",
+	}, rationales)
+}
+
+func TestParseSyntax_CommentWithoutSpace(t *testing.T) {
+	f, err := ParseSyntaxFromString("module github.com/example/module\n\nrequire example.com/x v1.0.0//indirect\n")
+	require.NoError(t, err)
+	require.Len(t, f.Module.Requires, 1)
+	assert.Equal(t, "v1.0.0", f.Module.Requires[0].Version)
+	assert.True(t, f.Module.Requires[0].Indirect)
+
+	// 注释之后的内容不再作为参数
+	for _, content := range []string{
+		"module m\n\nrequire example.com//x v1.0.0\n",
+		"module m\n\nrequire (\n\texample.com//x v1.0.0\n)\n",
+		"module m\n\nexclude example.com/x//v1.0.0\n",
+		"module m\n\nreplace example.com/x //=> ./x\n",
+		"module m\n\ntool //example.com/x\n",
+	} {
+		_, err := ParseSyntaxFromString(content)
+		assert.Error(t, err, content)
+	}
+}
+
+func TestParseSyntax_Recover(t *testing.T) {
+	content := `module github.com/example/module
+
+require (
+	github.com/pkg/errors
+	github.com/stretchr/testify v1.8.4
+)
+`
+	f, err := ParseSyntax(strings.NewReader(content), Options{Recover: true})
+	require.Error(t, err)
+	require.NotNil(t, f)
+	assert.Len(t, f.Module.Requires, 1)
+	assert.Len(t, f.Syntax.Stmt, 2)
+
+	f, err = ParseSyntax(strings.NewReader(content), Options{})
+	assert.Nil(t, f)
+	assert.ErrorIs(t, err, ErrInvalidRequire)
+}
+
+func TestParseSyntaxFromFile(t *testing.T) {
+	tempDir := t.TempDir()
+	goModPath := filepath.Join(tempDir, "go.mod")
+	content := `module github.com/example/module
+
+replace github.com/example/lib => ./lib
+`
+	require.NoError(t, os.WriteFile(goModPath, []byte(content), 0644))
+
+	f, err := ParseSyntaxFromFile(goModPath)
+	require.NoError(t, err)
+	assert.Equal(t, goModPath, f.Syntax.Name)
+	assert.Equal(t, filepath.Join(tempDir, "lib"), f.Module.Replaces[0].New.Dir)
+
+	_, err = ParseSyntaxFromFile(filepath.Join(tempDir, "missing.mod"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,288 @@
+package parser
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+)
+
+// Format 将文件格式化为规范的go.mod文本，保留语法树中的所有注释
+func (f *File) Format() []byte {
+	return FormatSyntax(f.Syntax)
+}
+
+// WriteTo 将格式化后的go.mod文本写入w，实现io.WriterTo接口
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(f.Format())
+	return int64(n), err
+}
+
+// FormatOptions 控制由模块信息生成go.mod文本的方式
+type FormatOptions struct {
+	// ConsolidateRequires 为true时按照go 1.17及以后的go命令的方式输出require：
+	// 直接依赖和间接依赖分别放在两个块中，块内按模块路径和语义化版本排序
+	ConsolidateRequires bool
+}
+
+// FormatModule 将模块信息格式化为规范的go.mod文本
+// 没有语法树可用时使用：指令按 module、go、toolchain、godebug、require、tool、
+// exclude、replace、retract 的顺序输出，同一指令有多项时合并为块
+func FormatModule(mod *module.Module) []byte {
+	return FormatModuleWithOptions(mod, FormatOptions{})
+}
+
+// FormatModuleWithOptions 按照指定选项将模块信息格式化为规范的go.mod文本
+func FormatModuleWithOptions(mod *module.Module, opts FormatOptions) []byte {
+	syntax := syntaxFromModule(mod)
+	if opts.ConsolidateRequires {
+		consolidateRequires(syntax)
+	}
+	return FormatSyntax(syntax)
+}
+
+// FormatSyntax 将语法树格式化为规范的go.mod文本，不修改语法树
+// 格式与 go mod edit -fmt 一致：去掉重复的exclude、replace和tool语句，块内语句排序，
+// 去掉空块，只有一条语句的块写为单行指令；顶层语句之间以一个空行分隔，块内语句以制表符缩进，
+// 词法单元之间以一个空格分隔；已经是规范格式的文件格式化后与原文逐字节相同
+func FormatSyntax(f *FileSyntax) []byte {
+	f = cleanSyntax(f)
+	p := &printer{}
+	p.comments(f.Before, "")
+	for i, stmt := range f.Stmt {
+		if i > 0 {
+			p.WriteString("\n")
+		}
+		switch x := stmt.(type) {
+		case *CommentBlock:
+			p.comments(x.Before, "")
+		case *Line:
+			p.line(x, "")
+		case *LineBlock:
+			p.block(x)
+		}
+		p.comments(stmt.Comment().After, "")
+	}
+	p.comments(f.After, "")
+	return p.Bytes()
+}
+
+// printer 负责将语法树输出为文本
+type printer struct {
+	bytes.Buffer
+}
+
+// comments 输出整行注释，Token为空的注释输出为空行
+func (p *printer) comments(comments []Comment, indent string) {
+	for _, comment := range comments {
+		if comment.Token == "" {
+			p.WriteString("\n")
+			continue
+		}
+		p.WriteString(indent)
+		p.WriteString(strings.TrimSpace(comment.Token))
+		p.WriteString("\n")
+	}
+}
+
+// suffix 输出行尾注释
+// 与 go mod edit -fmt 一致，有多条行尾注释时从第二条开始各占一行，按indent缩进
+func (p *printer) suffix(comments []Comment, indent string) {
+	for i, comment := range comments {
+		if i == 0 {
+			p.WriteString(" ")
+		} else {
+			p.WriteString("\n")
+			p.WriteString(indent)
+		}
+		p.WriteString(strings.TrimSpace(comment.Token))
+	}
+}
+
+// line 输出一条语句及其注释
+func (p *printer) line(line *Line, indent string) {
+	p.comments(line.Before, indent)
+	p.WriteString(indent)
+	p.WriteString(joinTokens(line.Token))
+	p.suffix(line.Suffix, indent)
+	p.WriteString("\n")
+}
+
+// block 输出一个块及其注释
+func (p *printer) block(block *LineBlock) {
+	p.comments(block.Before, "")
+	p.WriteString(joinTokens(append(append([]string{}, block.Token...), "(")))
+	p.suffix(block.LParen.Suffix, "\t")
+	p.WriteString("\n")
+	for _, line := range block.Line {
+		p.line(line, "\t")
+		p.comments(line.After, "\t")
+	}
+	// 与 go mod edit -fmt 一致，右括号之前的注释不缩进
+	p.comments(block.RParen.Before, "")
+	p.WriteString(")")
+	p.suffix(block.RParen.Suffix, "")
+	p.WriteString("\n")
+}
+
+// syntaxFromModule 根据模块信息构造语法树
+func syntaxFromModule(mod *module.Module) *FileSyntax {
+	f := &FileSyntax{}
+
+	if mod.Name != "" {
+		line := &Line{Token: []string{"module", autoQuote(mod.Name)}}
+		if mod.Deprecated != "" {
+			for _, text := range strings.Split("Deprecated: "+mod.Deprecated, "\n") {
+				line.Before = append(line.Before, Comment{Token: strings.TrimSpace("// " + text)})
+			}
+		}
+		f.Stmt = append(f.Stmt, line)
+	}
+	if mod.GoVersion != "" {
+		f.Stmt = append(f.Stmt, &Line{Token: []string{"go", autoQuote(mod.GoVersion)}})
+	}
+	if mod.Toolchain != "" {
+		f.Stmt = append(f.Stmt, &Line{Token: []string{"toolchain", autoQuote(mod.Toolchain)}})
+	}
+
+	lines := make([]*Line, 0, len(mod.Godebugs))
+	for _, gd := range mod.Godebugs {
+		lines = append(lines, godebugLine(gd))
+	}
+	f.Stmt = appendDirective(f.Stmt, "godebug", lines)
+
+	lines = make([]*Line, 0, len(mod.Requires))
+	for _, req := range mod.Requires {
+		lines = append(lines, requireLine(req))
+	}
+	f.Stmt = appendDirective(f.Stmt, "require", lines)
+
+	lines = make([]*Line, 0, len(mod.Tools))
+	for _, tool := range mod.Tools {
+		lines = append(lines, toolLine(tool))
+	}
+	f.Stmt = appendDirective(f.Stmt, "tool", lines)
+
+	lines = make([]*Line, 0, len(mod.Excludes))
+	for _, exc := range mod.Excludes {
+		lines = append(lines, excludeLine(exc))
+	}
+	f.Stmt = appendDirective(f.Stmt, "exclude", lines)
+
+	lines = make([]*Line, 0, len(mod.Replaces))
+	for _, rep := range mod.Replaces {
+		lines = append(lines, replaceLine(rep))
+	}
+	f.Stmt = appendDirective(f.Stmt, "replace", lines)
+
+	lines = make([]*Line, 0, len(mod.Retracts))
+	for _, ret := range mod.Retracts {
+		lines = append(lines, retractLine(ret))
+	}
+	f.Stmt = appendDirective(f.Stmt, "retract", lines)
+
+	return f
+}
+
+// appendDirective 将同一指令的多条语句追加到语句列表
+// 只有一条语句时输出为单行指令，多条语句时合并为块
+func appendDirective(stmts []Expr, keyword string, lines []*Line) []Expr {
+	switch len(lines) {
+	case 0:
+		return stmts
+	case 1:
+		line := lines[0]
+		line.Token = append([]string{keyword}, line.Token...)
+		return append(stmts, line)
+	default:
+		for _, line := range lines {
+			line.InBlock = true
+		}
+		return append(stmts, &LineBlock{Token: []string{keyword}, Line: lines})
+	}
+}
+
+// godebugLine 构造godebug语句（不含关键字）
+func godebugLine(gd *module.Godebug) *Line {
+	return &Line{Token: []string{gd.Key + "=" + gd.Value}}
+}
+
+// requireLine 构造require语句（不含关键字）
+func requireLine(req *module.Require) *Line {
+	line := &Line{Token: []string{autoQuote(req.Path), autoQuote(req.Version)}}
+	if req.Indirect {
+		line.Suffix = []Comment{{Token: "// indirect", Suffix: true}}
+	}
+	return line
+}
+
+// toolLine 构造tool语句（不含关键字）
+func toolLine(tool *module.Tool) *Line {
+	return &Line{Token: []string{autoQuote(tool.Path)}}
+}
+
+// excludeLine 构造exclude语句（不含关键字）
+func excludeLine(exc *module.Exclude) *Line {
+	return &Line{Token: []string{autoQuote(exc.Path), autoQuote(exc.Version)}}
+}
+
+// replaceLine 构造replace语句（不含关键字）
+func replaceLine(rep *module.Replace) *Line {
+	tokens := []string{autoQuote(rep.Old.Path)}
+	if rep.Old.Version != "" {
+		tokens = append(tokens, autoQuote(rep.Old.Version))
+	}
+	tokens = append(tokens, "=>", autoQuote(rep.New.Path))
+	if rep.New.Version != "" {
+		tokens = append(tokens, autoQuote(rep.New.Version))
+	}
+	return &Line{Token: tokens}
+}
+
+// retractLine 构造retract语句（不含关键字），撤回理由作为行尾注释
+func retractLine(ret *module.Retract) *Line {
+	var line *Line
+	if ret.VersionLow != "" || ret.VersionHigh != "" {
+		line = &Line{Token: []string{"[", autoQuote(ret.VersionLow), ",", autoQuote(ret.VersionHigh), "]"}}
+	} else {
+		line = &Line{Token: []string{autoQuote(ret.Version)}}
+	}
+	line.Before = rationaleComments(ret.Rationale)
+	return line
+}
+
+// rationaleComments 将撤回理由写为语句之前的注释，与go命令一样每行一条
+func rationaleComments(rationale string) []Comment {
+	if rationale == "" {
+		return nil
+	}
+	var comments []Comment
+	for _, text := range strings.Split(rationale, "\n") {
+		comments = append(comments, Comment{Token: strings.TrimSpace("// " + text)})
+	}
+	return comments
+}
+
+// autoQuote 在需要时为词法单元加上引号，使其能被重新解析为同一个值
+func autoQuote(s string) string {
+	if mustQuote(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// mustQuote 检查字符串作为词法单元时是否必须加引号
+func mustQuote(s string) bool {
+	if s == "" || strings.Contains(s, "//") || strings.Contains(s, "=>") {
+		return true
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isSpace(c) || isPunctuation(c) || c == '"' || c == '`' || c == '\'' || c == '\n' || c < 0x20 || c == 0x7f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,287 @@
+package parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat_CanonicalRoundTrip(t *testing.T) {
+	contents := []string{
+		"",
+		"module github.com/example/module\n",
+		`// Project header.
+
+// Deprecated: use github.com/example/module/v2 instead.
+module github.com/example/module
+
+go 1.23.0
+
+toolchain go1.23.2
+
+godebug (
+	asynctimerchan=0
+	panicnil=1
+)
+
+require github.com/single/dep v1.0.0 // indirect
+
+require ( // direct dependencies
+	// testify is used in tests
+	github.com/stretchr/testify v1.8.4
+
+	golang.org/x/text v0.12.0
+// trailing comment in block
+) // end of require
+
+require (
+	"github.com/quoted path" v1.0.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+)
+
+tool golang.org/x/tools/cmd/stringer
+
+exclude github.com/bad/pkg v1.0.0
+
+replace (
+	github.com/local/pkg => ../local
+	github.com/old/pkg v1.2.3 => github.com/new/pkg v1.4.0
+)
+
+retract (
+	[v1.0.2, v1.0.5] // broken builds
+	v1.0.1 // security issue
+)
+
+// footer comment
+`,
+		// 右括号之前的注释与 go mod edit -fmt 一样从第一列开始
+		`module github.com/example/module
+
+require (
+	github.com/example/a v1.0.0
+// trailing in block
+
+// after blank
+)
+
+retract (
+	v1.0.0 // broken
+// end
+)
+`,
+	}
+
+	for _, content := range contents {
+		f, err := ParseSyntaxFromString(content)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(f.Format()))
+	}
+}
+
+// TestFormat_MatchesGoModEditFmt 的期望结果由 go mod edit -fmt 生成
+func TestFormat_MatchesGoModEditFmt(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "collapse block with one entry",
+			content:  "module m\n\nrequire (\n\ta.com/x v1.0.0\n)\n",
+			expected: "module m\n\nrequire a.com/x v1.0.0\n",
+		},
+		{
+			name:     "drop empty block",
+			content:  "module m\n\nrequire (\n)\n\ngo 1.21\n",
+			expected: "module m\n\ngo 1.21\n",
+		},
+		{
+			name:     "collapse keeps comments",
+			content:  "module m\n\n// before\nrequire (\n\t// line\n\ta.com/x v1.0.0 // s\n)\n",
+			expected: "module m\n\n// before\n// line\nrequire a.com/x v1.0.0 // s\n",
+		},
+		{
+			name:     "keep block with comment before closing paren",
+			content:  "module m\n\nrequire (\n\ta.com/x v1.0.0\n// keep\n)\n",
+			expected: "module m\n\nrequire (\n\ta.com/x v1.0.0\n// keep\n)\n",
+		},
+		{
+			name:     "keep blank lines around comment before closing paren",
+			content:  "module m\n\nrequire (\n\ta.com/x v1.0.0\n\n// x\n\n)\n",
+			expected: "module m\n\nrequire (\n\ta.com/x v1.0.0\n\n// x\n\n)\n",
+		},
+		{
+			name:     "sort retract intervals in descending order",
+			content:  "module m\n\nretract (\n\tv1.0.0\n\t[v1.1.0, v1.2.0]\n\tv1.1.0\n\t[v1.1.0, v1.3.0]\n)\n",
+			expected: "module m\n\nretract (\n\t[v1.1.0, v1.3.0]\n\t[v1.1.0, v1.2.0]\n\tv1.1.0\n\tv1.0.0\n)\n",
+		},
+		{
+			name:     "sort excludes semantically since go 1.21 and drop duplicates",
+			content:  "module m\n\ngo 1.21\n\nexclude (\n\ta.com/x v1.10.0\n\ta.com/x v1.9.0\n\ta.com/x v1.9.0\n)\n",
+			expected: "module m\n\ngo 1.21\n\nexclude (\n\ta.com/x v1.9.0\n\ta.com/x v1.10.0\n)\n",
+		},
+		{
+			name:     "sort excludes lexically before go 1.21",
+			content:  "module m\n\ngo 1.20\n\nexclude (\n\ta.com/x v1.10.0\n\ta.com/x v1.9.0\n)\n",
+			expected: "module m\n\ngo 1.20\n\nexclude (\n\ta.com/x v1.10.0\n\ta.com/x v1.9.0\n)\n",
+		},
+		{
+			name:     "later replace wins and duplicate tool is dropped",
+			content:  "module m\n\nreplace a.com/x => ../x\n\nreplace (\n\tb.com/y => ../y\n\ta.com/x => ../x2\n)\n\ntool (\n\ta.com/t\n\ta.com/t\n)\n",
+			expected: "module m\n\nreplace (\n\ta.com/x => ../x2\n\tb.com/y => ../y\n)\n\ntool a.com/t\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseSyntaxFromString(tt.content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(f.Format()))
+
+			// 格式化不修改语法树
+			fresh, err := ParseSyntaxFromString(tt.content)
+			require.NoError(t, err)
+			assert.Equal(t, fresh.Syntax, f.Syntax)
+
+			again, err := ParseSyntaxFromString(tt.expected)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(again.Format()))
+		})
+	}
+}
+
+func TestFormat_Normalizes(t *testing.T) {
+	content := "module   github.com/example/module\n" +
+		"go 1.21\n" +
+		"\n\n\n" +
+		"require (\n" +
+		"\n" +
+		"    github.com/stretchr/testify   v1.8.4   //   indirect\n" +
+		"\n\n" +
+		"  github.com/pkg/errors v0.9.1\n" +
+		"\n" +
+		")\n" +
+		"retract [ v1.0.0 ,v1.0.5 ]\n"
+
+	// 与 go mod edit -fmt 一样，排序后空行随语句移到块的开头，再次格式化时去掉
+	expected := `module github.com/example/module
+
+go 1.21
+
+require (
+
+	github.com/pkg/errors v0.9.1
+	github.com/stretchr/testify v1.8.4 //   indirect
+)
+
+retract [v1.0.0, v1.0.5]
+`
+	f, err := ParseSyntaxFromString(content)
+	require.NoError(t, err)
+	formatted := f.Format()
+	assert.Equal(t, expected, string(formatted))
+
+	again, err := ParseSyntaxFromString(string(formatted))
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(expected, "(\n\n", "(\n", 1), string(again.Format()))
+	assert.ElementsMatch(t, f.Module.Requires, again.Module.Requires)
+	assert.Equal(t, f.Module.Retracts, again.Module.Retracts)
+}
+
+func TestFormatModule(t *testing.T) {
+	mod := &module.Module{
+		Name:       "github.com/example/module",
+		Deprecated: "use v2",
+		GoVersion:  "1.23",
+		Toolchain:  "go1.23.2",
+		Godebugs:   []*module.Godebug{{Key: "panicnil", Value: "1"}},
+		Requires: []*module.Require{
+			{Path: "github.com/pkg/errors", Version: "v0.9.1", Indirect: true},
+			{Path: "github.com/stretchr/testify", Version: "v1.8.4"},
+		},
+		Tools: []*module.Tool{{Path: "golang.org/x/tools/cmd/stringer"}},
+		Replaces: []*module.Replace{
+			{
+				Old: &module.ReplaceItem{Path: "github.com/old/pkg", Version: "v1.0.0"},
+				New: &module.ReplaceItem{Path: "../pkg"},
+			},
+		},
+		Excludes: []*module.Exclude{{Path: "github.com/bad/pkg", Version: "v1.0.0"}},
+		Retracts: []*module.Retract{
+			{VersionLow: "v1.0.2", VersionHigh: "v1.0.5", Rationale: "broken\nbuilds"},
+			{Version: "v1.0.1", Rationale: "security issue"},
+		},
+	}
+
+	expected := `// Deprecated: use v2
+module github.com/example/module
+
+go 1.23
+
+toolchain go1.23.2
+
+godebug panicnil=1
+
+require (
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/stretchr/testify v1.8.4
+)
+
+tool golang.org/x/tools/cmd/stringer
+
+exclude github.com/bad/pkg v1.0.0
+
+replace github.com/old/pkg v1.0.0 => ../pkg
+
+retract (
+	// broken
+	// builds
+	[v1.0.2, v1.0.5]
+	// security issue
+	v1.0.1
+)
+`
+	assert.Equal(t, expected, string(FormatModule(mod)))
+
+	// 格式化结果可以被重新解析为相同的模块信息
+	parsed, err := ParseFromString(expected)
+	require.NoError(t, err)
+	assert.Equal(t, mod.Name, parsed.Name)
+	assert.Equal(t, mod.Deprecated, parsed.Deprecated)
+	assert.Equal(t, mod.Requires, parsed.Requires)
+	assert.Equal(t, mod.Replaces, parsed.Replaces)
+	assert.Equal(t, mod.Retracts, parsed.Retracts)
+}
+
+func TestFormatModule_QuotesTokens(t *testing.T) {
+	mod := &module.Module{
+		Name: "example.com/with space",
+		Requires: []*module.Require{
+			{Path: "example.com/a//b", Version: "v1.0.0"},
+		},
+	}
+	formatted := string(FormatModule(mod))
+	assert.Equal(t, "module \"example.com/with space\"\n\nrequire \"example.com/a//b\" v1.0.0\n", formatted)
+
+	parsed, err := ParseFromString(formatted)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/with space", parsed.Name)
+	assert.Equal(t, "example.com/a//b", parsed.Requires[0].Path)
+}
+
+func TestFile_WriteTo(t *testing.T) {
+	content := "module github.com/example/module\n\ngo 1.21\n"
+	f, err := ParseSyntaxFromString(content)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := f.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), n)
+	assert.Equal(t, content, buf.String())
+}
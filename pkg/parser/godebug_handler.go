@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+)
+
+// parseGodebugSingleLine 解析单行godebug语句
+func parseGodebugSingleLine(mod *module.Module, line string) (bool, error) {
+	if matches := singleGodebugRegexp.FindStringSubmatch(line); len(matches) == 2 {
+		if err := parseGodebugBlockLine(mod, matches[1]); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// parseGodebugBlockLine 解析godebug块内的语句，格式为 key=value
+func parseGodebugBlockLine(mod *module.Module, line string) error {
+	parts := strings.Fields(line)
+	if len(parts) < 1 {
+		return ErrInvalidGodebug
+	}
+
+	// 允许行尾注释，但键值对本身不能包含空白
+	if len(parts) > 1 && !strings.HasPrefix(parts[1], "//") {
+		return ErrInvalidGodebug
+	}
+
+	key, value, ok := strings.Cut(parts[0], "=")
+	if !ok || key == "" || strings.ContainsAny(key, "\"`',") || strings.ContainsAny(value, "\"`',") {
+		return ErrInvalidGodebug
+	}
+
+	mod.Godebugs = append(mod.Godebugs, &module.Godebug{
+		Key:   key,
+		Value: value,
+	})
+	return nil
+}
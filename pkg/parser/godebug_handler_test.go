@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGodebugSingleLine(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		expectHandled bool
+		expectKey     string
+		expectValue   string
+		expectError   bool
+	}{
+		{
+			name:          "valid godebug",
+			line:          "godebug panicnil=1",
+			expectHandled: true,
+			expectKey:     "panicnil",
+			expectValue:   "1",
+			expectError:   false,
+		},
+		{
+			name:          "godebug with empty value",
+			line:          "godebug default=",
+			expectHandled: true,
+			expectKey:     "default",
+			expectValue:   "",
+			expectError:   false,
+		},
+		{
+			name:          "not a godebug line",
+			line:          "module github.com/example/module",
+			expectHandled: false,
+			expectError:   false,
+		},
+		{
+			name:          "missing value separator",
+			line:          "godebug panicnil",
+			expectHandled: false,
+			expectError:   true,
+		},
+		{
+			name:          "extra tokens",
+			line:          "godebug panicnil=1 asynctimerchan=0",
+			expectHandled: false,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mod := &module.Module{}
+			handled, err := parseGodebugSingleLine(mod, tt.line)
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrInvalidGodebug)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectHandled, handled)
+
+			if tt.expectHandled {
+				assert.Equal(t, 1, len(mod.Godebugs))
+				assert.Equal(t, tt.expectKey, mod.Godebugs[0].Key)
+				assert.Equal(t, tt.expectValue, mod.Godebugs[0].Value)
+			} else {
+				assert.Equal(t, 0, len(mod.Godebugs))
+			}
+		})
+	}
+}
+
+func TestParseGodebugBlockLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		expectKey   string
+		expectValue string
+		expectError bool
+	}{
+		{
+			name:        "valid godebug in block",
+			line:        "asynctimerchan=0",
+			expectKey:   "asynctimerchan",
+			expectValue: "0",
+			expectError: false,
+		},
+		{
+			name:        "godebug with trailing comment",
+			line:        "panicnil=1 // keep old behavior",
+			expectKey:   "panicnil",
+			expectValue: "1",
+			expectError: false,
+		},
+		{
+			name:        "missing key",
+			line:        "=1",
+			expectError: true,
+		},
+		{
+			name:        "quoted value",
+			line:        `panicnil="1"`,
+			expectError: true,
+		},
+		{
+			name:        "empty line",
+			line:        "",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mod := &module.Module{}
+			err := parseGodebugBlockLine(mod, tt.line)
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrInvalidGodebug)
+				assert.Equal(t, 0, len(mod.Godebugs))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, len(mod.Godebugs))
+				assert.Equal(t, tt.expectKey, mod.Godebugs[0].Key)
+				assert.Equal(t, tt.expectValue, mod.Godebugs[0].Value)
+			}
+		})
+	}
+}
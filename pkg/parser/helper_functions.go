@@ -1,9 +1,52 @@
 package parser
 
 import (
+	"strings"
+
+	"github.com/scagogogo/go-mod-parser/pkg/gover"
 	"github.com/scagogogo/go-mod-parser/pkg/module"
 )
 
+// EffectiveToolchain 返回模块实际使用的Go工具链名称
+// 存在toolchain声明时返回其值，否则根据go声明推导出默认工具链（例如 go 1.21 对应 go1.21），
+// 两者都不存在时返回空字符串
+func EffectiveToolchain(mod *module.Module) string {
+	if mod.Toolchain != "" && mod.Toolchain != "default" {
+		return mod.Toolchain
+	}
+	if mod.GoVersion != "" {
+		return "go" + mod.GoVersion
+	}
+	return ""
+}
+
+// EffectiveToolchainVersion 返回模块实际使用的Go工具链版本，不含go前缀和自定义后缀
+// 例如 toolchain go1.21.5-custom 对应的版本为 1.21.5；无法确定合法的版本时返回空字符串
+func EffectiveToolchainVersion(mod *module.Module) string {
+	return gover.FromToolchain(EffectiveToolchain(mod))
+}
+
+// SupportsFeature 检查模块的go版本是否支持某个取决于go版本的特性，例如模块图修剪（go 1.17）
+// 没有go声明时按照go命令的默认值 1.16 判断
+func SupportsFeature(mod *module.Module, feature gover.Feature) bool {
+	return gover.Supports(mod.GoVersion, feature)
+}
+
+// HasGodebug 检查模块是否有特定的godebug设置
+func HasGodebug(mod *module.Module, key string) bool {
+	return GetGodebug(mod, key) != nil
+}
+
+// GetGodebug 获取模块的特定godebug设置
+func GetGodebug(mod *module.Module, key string) *module.Godebug {
+	for _, gd := range mod.Godebugs {
+		if gd.Key == key {
+			return gd
+		}
+	}
+	return nil
+}
+
 // HasRequire 检查模块是否有特定的依赖
 func HasRequire(mod *module.Module, path string) bool {
 	return GetRequire(mod, path) != nil
@@ -19,6 +62,53 @@ func GetRequire(mod *module.Module, path string) *module.Require {
 	return nil
 }
 
+// GetIncompatibleRequires 返回所有使用+incompatible版本的依赖
+// 这些依赖的主版本不低于v2，但没有使用带主版本后缀的模块路径
+func GetIncompatibleRequires(mod *module.Module) []*module.Require {
+	var requires []*module.Require
+	for _, req := range mod.Requires {
+		if module.IsIncompatible(req.Version) {
+			requires = append(requires, req)
+		}
+	}
+	return requires
+}
+
+// HasTool 检查模块是否声明了特定的工具
+func HasTool(mod *module.Module, path string) bool {
+	for _, tool := range mod.Tools {
+		if tool.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// GetToolRequire 获取提供指定工具的依赖项
+// 工具路径按最长模块路径前缀匹配到对应的require，工具由主模块自身提供或没有匹配的依赖时返回nil
+func GetToolRequire(mod *module.Module, toolPath string) *module.Require {
+	var best *module.Require
+	for _, req := range mod.Requires {
+		if !pathHasPrefix(toolPath, req.Path) {
+			continue
+		}
+		if best == nil || len(req.Path) > len(best.Path) {
+			best = req
+		}
+	}
+
+	// 主模块比匹配到的依赖更具体时，工具来自主模块本身
+	if best != nil && mod.Name != "" && pathHasPrefix(toolPath, mod.Name) && len(mod.Name) > len(best.Path) {
+		return nil
+	}
+	return best
+}
+
+// pathHasPrefix 检查path是否等于prefix或位于prefix之下
+func pathHasPrefix(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
 // HasReplace 检查模块是否有特定的替换规则
 func HasReplace(mod *module.Module, path string) bool {
 	return GetReplace(mod, path) != nil
@@ -44,20 +134,18 @@ func HasExclude(mod *module.Module, path, version string) bool {
 	return false
 }
 
-// HasRetract 检查模块是否有特定的撤回版本
+// HasRetract 检查模块是否撤回了特定的版本，版本按照语义化版本比较，
+// 例如 v1.10.0 位于 [v1.9.0, v1.11.0] 范围内
 func HasRetract(mod *module.Module, version string) bool {
+	return GetRetract(mod, version) != nil
+}
+
+// GetRetract 获取撤回了特定版本的retract声明，多个声明都包含该版本时返回第一个
+func GetRetract(mod *module.Module, version string) *module.Retract {
 	for _, ret := range mod.Retracts {
-		// 检查单个版本
-		if ret.Version == version {
-			return true
-		}
-		// 检查版本范围
-		if ret.VersionLow != "" && ret.VersionHigh != "" {
-			// 这里我们只做简单的字符串比较，实际上需要更复杂的版本比较逻辑
-			if version >= ret.VersionLow && version <= ret.VersionHigh {
-				return true
-			}
+		if ret.Contains(version) {
+			return ret
 		}
 	}
-	return false
+	return nil
 }
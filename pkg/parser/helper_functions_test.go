@@ -3,6 +3,7 @@ package parser_test
 import (
 	"testing"
 
+	"github.com/scagogogo/go-mod-parser/pkg/gover"
 	"github.com/scagogogo/go-mod-parser/pkg/module"
 	"github.com/scagogogo/go-mod-parser/pkg/parser"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,9 @@ func TestHelperFunctions(t *testing.T) {
 	mod := &module.Module{
 		Name:      "example.com/test",
 		GoVersion: "1.18",
+		Godebugs: []*module.Godebug{
+			{Key: "panicnil", Value: "1"},
+		},
 		Requires: []*module.Require{
 			{Path: "github.com/test/req1", Version: "v1.0.0"},
 			{Path: "github.com/test/req2", Version: "v2.0.0", Indirect: true},
@@ -43,6 +47,16 @@ func TestHelperFunctions(t *testing.T) {
 
 	assert.Nil(t, parser.GetRequire(mod, "github.com/test/nonexistent"))
 
+	// 测试 HasGodebug 和 GetGodebug
+	assert.True(t, parser.HasGodebug(mod, "panicnil"))
+	assert.False(t, parser.HasGodebug(mod, "asynctimerchan"))
+
+	gd := parser.GetGodebug(mod, "panicnil")
+	assert.NotNil(t, gd)
+	assert.Equal(t, "1", gd.Value)
+
+	assert.Nil(t, parser.GetGodebug(mod, "asynctimerchan"))
+
 	// 测试 HasReplace 和 GetReplace
 	assert.True(t, parser.HasReplace(mod, "github.com/test/old1"))
 	assert.False(t, parser.HasReplace(mod, "github.com/test/nonexistent"))
@@ -63,3 +77,150 @@ func TestHelperFunctions(t *testing.T) {
 	assert.True(t, parser.HasRetract(mod, "v2.5.0")) // 在范围 [v2.0.0, v2.9.9] 内
 	assert.False(t, parser.HasRetract(mod, "v3.0.0"))
 }
+
+func TestHasRetract_SemanticVersions(t *testing.T) {
+	mod := &module.Module{
+		Retracts: []*module.Retract{
+			{VersionLow: "v1.9.0", VersionHigh: "v1.11.0"},
+			{Version: "v2.0.0"},
+			{Version: "bad-version"},
+		},
+	}
+
+	tests := []struct {
+		version  string
+		expected bool
+	}{
+		{"v1.10.0", true}, // 按字符串比较时会被误判为小于 v1.9.0
+		{"v1.9.0", true},
+		{"v1.11.0", true},
+		{"v1.11.0-rc.1", true},
+		{"v1.9.0-rc.1", false},
+		{"v1.2.0", false},
+		{"v1.100.0", false},
+		{"v2.0.0+meta", true},
+		{"v2", true},
+		{"bad-version", true},
+		{"other", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parser.HasRetract(mod, tt.version))
+		})
+	}
+}
+
+func TestEffectiveToolchain(t *testing.T) {
+	tests := []struct {
+		name            string
+		mod             *module.Module
+		expected        string
+		expectedVersion string
+	}{
+		{
+			name:            "explicit toolchain",
+			mod:             &module.Module{GoVersion: "1.21", Toolchain: "go1.21.5"},
+			expected:        "go1.21.5",
+			expectedVersion: "1.21.5",
+		},
+		{
+			name:            "toolchain with custom suffix",
+			mod:             &module.Module{GoVersion: "1.22.0", Toolchain: "go1.22.1-custom"},
+			expected:        "go1.22.1-custom",
+			expectedVersion: "1.22.1",
+		},
+		{
+			name:            "derived from go version",
+			mod:             &module.Module{GoVersion: "1.20"},
+			expected:        "go1.20",
+			expectedVersion: "1.20",
+		},
+		{
+			name:            "toolchain default",
+			mod:             &module.Module{GoVersion: "1.21.0", Toolchain: "default"},
+			expected:        "go1.21.0",
+			expectedVersion: "1.21.0",
+		},
+		{
+			name:            "neither go nor toolchain",
+			mod:             &module.Module{},
+			expected:        "",
+			expectedVersion: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parser.EffectiveToolchain(tt.mod))
+			assert.Equal(t, tt.expectedVersion, parser.EffectiveToolchainVersion(tt.mod))
+		})
+	}
+}
+
+func TestSupportsFeature(t *testing.T) {
+	mod := &module.Module{GoVersion: "1.21rc1"}
+	assert.True(t, parser.SupportsFeature(mod, gover.FeatureLazyLoading))
+	assert.True(t, parser.SupportsFeature(mod, gover.FeatureToolchain))
+	assert.False(t, parser.SupportsFeature(mod, gover.FeatureTool))
+
+	// 没有go声明时按 1.16 处理
+	assert.False(t, parser.SupportsFeature(&module.Module{}, gover.FeatureLazyLoading))
+}
+
+func TestGetToolRequire(t *testing.T) {
+	mod := &module.Module{
+		Name: "example.com/test",
+		Requires: []*module.Require{
+			{Path: "golang.org/x/tools", Version: "v0.20.0"},
+			{Path: "golang.org/x/tools/gopls", Version: "v0.15.0"},
+			{Path: "golang.org/x/toolsextra", Version: "v1.0.0"},
+		},
+		Tools: []*module.Tool{
+			{Path: "golang.org/x/tools/cmd/stringer"},
+			{Path: "golang.org/x/tools/gopls"},
+			{Path: "example.com/test/cmd/gen"},
+		},
+	}
+
+	assert.True(t, parser.HasTool(mod, "golang.org/x/tools/cmd/stringer"))
+	assert.False(t, parser.HasTool(mod, "golang.org/x/tools/cmd/goimports"))
+
+	req := parser.GetToolRequire(mod, "golang.org/x/tools/cmd/stringer")
+	assert.NotNil(t, req)
+	assert.Equal(t, "golang.org/x/tools", req.Path)
+
+	// 最长前缀优先
+	req = parser.GetToolRequire(mod, "golang.org/x/tools/gopls")
+	assert.NotNil(t, req)
+	assert.Equal(t, "golang.org/x/tools/gopls", req.Path)
+
+	req = parser.GetToolRequire(mod, "golang.org/x/tools/gopls/internal/cmd")
+	assert.NotNil(t, req)
+	assert.Equal(t, "golang.org/x/tools/gopls", req.Path)
+
+	// 前缀必须在路径元素边界上匹配
+	req = parser.GetToolRequire(mod, "golang.org/x/toolsextra/cmd/x")
+	assert.NotNil(t, req)
+	assert.Equal(t, "golang.org/x/toolsextra", req.Path)
+
+	// 主模块自身提供的工具
+	assert.Nil(t, parser.GetToolRequire(mod, "example.com/test/cmd/gen"))
+	assert.Nil(t, parser.GetToolRequire(mod, "github.com/unknown/tool"))
+}
+
+func TestGetIncompatibleRequires(t *testing.T) {
+	mod := &module.Module{
+		Requires: []*module.Require{
+			{Path: "github.com/docker/docker", Version: "v24.0.7+incompatible"},
+			{Path: "github.com/stretchr/testify", Version: "v1.8.4"},
+			{Path: "github.com/example/meta", Version: "v1.0.0+meta"},
+		},
+	}
+
+	requires := parser.GetIncompatibleRequires(mod)
+	assert.Len(t, requires, 1)
+	assert.Equal(t, "github.com/docker/docker", requires[0].Path)
+
+	assert.Empty(t, parser.GetIncompatibleRequires(&module.Module{}))
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"path/filepath"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/scagogogo/go-mod-parser/pkg/utils"
+)
+
+// LocalReplaceStatus 描述本地目录替换的目标状态
+type LocalReplaceStatus struct {
+	// Replace 对应的replace指令
+	Replace *module.Replace
+
+	// Dir 实际检查的目录
+	Dir string
+
+	// Exists 目标目录是否存在
+	Exists bool
+
+	// HasGoMod 目标目录中是否包含go.mod文件
+	HasGoMod bool
+}
+
+// OK 检查本地替换是否可用，即目标目录存在且包含自己的go.mod文件
+func (s *LocalReplaceStatus) OK() bool {
+	return s.Exists && s.HasGoMod
+}
+
+// resolveLocalReplaces 将本地目录替换的目标相对于go.mod所在目录解析为绝对路径
+func resolveLocalReplaces(mod *module.Module, modDir string) {
+	for _, rep := range mod.Replaces {
+		if !rep.IsLocal() {
+			continue
+		}
+		dir := filepath.FromSlash(rep.New.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(modDir, dir)
+		}
+		rep.New.Dir = filepath.Clean(dir)
+	}
+}
+
+// fileDir 返回文件所在目录的绝对路径，无法获取当前工作目录时返回原样的目录
+func fileDir(fileName string) string {
+	dir := filepath.Dir(fileName)
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return dir
+}
+
+// CheckLocalReplace 检查本地目录替换的目标目录是否存在并包含go.mod文件
+// 替换目标不是本地目录时返回nil；没有记录Dir时使用相对于当前工作目录的路径
+func CheckLocalReplace(rep *module.Replace) *LocalReplaceStatus {
+	if !rep.IsLocal() {
+		return nil
+	}
+
+	dir := rep.New.Dir
+	if dir == "" {
+		dir = filepath.FromSlash(rep.New.Path)
+	}
+
+	return &LocalReplaceStatus{
+		Replace:  rep,
+		Dir:      dir,
+		Exists:   utils.IsDir(dir),
+		HasGoMod: utils.IsFile(filepath.Join(dir, "go.mod")),
+	}
+}
+
+// CheckLocalReplaces 检查模块中所有本地目录替换的目标状态
+func CheckLocalReplaces(mod *module.Module) []*LocalReplaceStatus {
+	statuses := make([]*LocalReplaceStatus, 0)
+	for _, rep := range mod.Replaces {
+		if status := CheckLocalReplace(rep); status != nil {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFromFile_ResolvesLocalReplaces(t *testing.T) {
+	tempDir := t.TempDir()
+	modDir := filepath.Join(tempDir, "app")
+	require.NoError(t, os.MkdirAll(modDir, 0755))
+
+	content := `module github.com/example/app
+
+go 1.21
+
+replace (
+	github.com/example/lib => ../lib
+	github.com/example/abs => /opt/abs
+	github.com/example/remote => github.com/fork/remote v1.0.0
+)
+`
+	goModPath := filepath.Join(modDir, "go.mod")
+	require.NoError(t, os.WriteFile(goModPath, []byte(content), 0644))
+
+	mod, err := ParseFromFile(goModPath)
+	require.NoError(t, err)
+	require.Len(t, mod.Replaces, 3)
+
+	assert.Equal(t, module.ReplaceKindLocal, mod.Replaces[0].Kind())
+	assert.Equal(t, filepath.Join(tempDir, "lib"), mod.Replaces[0].New.Dir)
+	assert.Equal(t, filepath.Clean("/opt/abs"), mod.Replaces[1].New.Dir)
+	assert.Equal(t, module.ReplaceKindModule, mod.Replaces[2].Kind())
+	assert.Equal(t, "", mod.Replaces[2].New.Dir)
+}
+
+func TestParseWithOptions_ResolvesLocalReplaces(t *testing.T) {
+	content := "module github.com/example/app\n\nreplace github.com/example/lib => ../lib\n"
+	modDir := filepath.Join(t.TempDir(), "app")
+
+	// 设置FileName后相对于该文件所在目录解析，文件不需要存在
+	mod, err := ParseWithOptions(strings.NewReader(content), Options{FileName: filepath.Join(modDir, "go.mod")})
+	require.NoError(t, err)
+	require.Len(t, mod.Replaces, 1)
+	assert.Equal(t, filepath.Join(filepath.Dir(modDir), "lib"), mod.Replaces[0].New.Dir)
+
+	// 相对的FileName相对于当前工作目录解析
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	f, err := ParseSyntax(strings.NewReader(content), Options{FileName: filepath.Join("app", "go.mod")})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(wd, "lib"), f.Module.Replaces[0].New.Dir)
+
+	// 未设置FileName时不解析
+	mod, err = ParseWithOptions(strings.NewReader(content), Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "", mod.Replaces[0].New.Dir)
+}
+
+func TestCheckLocalReplaces(t *testing.T) {
+	tempDir := t.TempDir()
+	modDir := filepath.Join(tempDir, "app")
+	require.NoError(t, os.MkdirAll(modDir, 0755))
+
+	// lib 目录存在且有go.mod，nomod 目录存在但没有go.mod，missing 目录不存在
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "lib"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "lib", "go.mod"), []byte("module github.com/example/lib\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "nomod"), 0755))
+
+	content := `module github.com/example/app
+
+replace github.com/example/lib => ../lib
+
+replace github.com/example/nomod => ../nomod
+
+replace github.com/example/missing => ../missing
+
+replace github.com/example/remote => github.com/fork/remote v1.0.0
+`
+	goModPath := filepath.Join(modDir, "go.mod")
+	require.NoError(t, os.WriteFile(goModPath, []byte(content), 0644))
+
+	mod, err := ParseFromFile(goModPath)
+	require.NoError(t, err)
+
+	statuses := CheckLocalReplaces(mod)
+	require.Len(t, statuses, 3)
+
+	assert.Equal(t, "github.com/example/lib", statuses[0].Replace.Old.Path)
+	assert.True(t, statuses[0].Exists)
+	assert.True(t, statuses[0].HasGoMod)
+	assert.True(t, statuses[0].OK())
+
+	assert.True(t, statuses[1].Exists)
+	assert.False(t, statuses[1].HasGoMod)
+	assert.False(t, statuses[1].OK())
+
+	assert.Equal(t, filepath.Join(tempDir, "missing"), statuses[2].Dir)
+	assert.False(t, statuses[2].Exists)
+	assert.False(t, statuses[2].OK())
+
+	assert.Nil(t, CheckLocalReplace(mod.Replaces[3]))
+}
@@ -18,6 +18,132 @@ go 1.21
 	assert.Equal(t, "1.21", mod.GoVersion)
 }
 
+func TestParseFromString_Deprecated(t *testing.T) {
+	content := `// Deprecated: use github.com/example/module/v2 instead.
+module github.com/example/module
+
+go 1.21
+`
+	mod, err := ParseFromString(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/example/module", mod.Name)
+	assert.Equal(t, "use github.com/example/module/v2 instead.", mod.Deprecated)
+
+	// 与module声明之间隔有空行的注释不属于该声明
+	content = `// Deprecated: use github.com/example/module/v2 instead.
+
+module github.com/example/module
+`
+	mod, err = ParseFromString(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "", mod.Deprecated)
+
+	content = `module github.com/example/module // Deprecated: archived
+`
+	mod, err = ParseFromString(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/example/module", mod.Name)
+	assert.Equal(t, "archived", mod.Deprecated)
+}
+
+func TestParseFromString_QuotedStrings(t *testing.T) {
+	content := "module \"github.com/example/module\"\n" +
+		"\n" +
+		"go 1.21\n" +
+		"\n" +
+		"require \"github.com/stretchr/testify\" v1.8.4\n" +
+		"\n" +
+		"require (\n" +
+		"\t`github.com/pkg/errors` \"v0.9.1\" // indirect\n" +
+		")\n" +
+		"\n" +
+		"replace \"github.com/old/pkg\" => \"github.com/new/pkg\" v1.0.0\n" +
+		"\n" +
+		"exclude \"github.com/bad/pkg\" v1.0.0\n" +
+		"\n" +
+		"retract [\"v1.0.0\", `v1.0.5`] // broken\n"
+	mod, err := ParseFromString(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/example/module", mod.Name)
+	assert.Equal(t, "github.com/stretchr/testify", mod.Requires[0].Path)
+	assert.Equal(t, "github.com/pkg/errors", mod.Requires[1].Path)
+	assert.Equal(t, "v0.9.1", mod.Requires[1].Version)
+	assert.True(t, mod.Requires[1].Indirect)
+	assert.Equal(t, "github.com/old/pkg", mod.Replaces[0].Old.Path)
+	assert.Equal(t, "github.com/new/pkg", mod.Replaces[0].New.Path)
+	assert.Equal(t, "github.com/bad/pkg", mod.Excludes[0].Path)
+	assert.Equal(t, "v1.0.0", mod.Retracts[0].VersionLow)
+	assert.Equal(t, "v1.0.5", mod.Retracts[0].VersionHigh)
+}
+
+func TestParseFromString_MalformedQuotedString(t *testing.T) {
+	content := `module github.com/example/module
+
+require (
+	github.com/pkg/errors "v0.9.1\q"
+)
+`
+	_, err := ParseFromString(content)
+	assert.ErrorIs(t, err, ErrInvalidQuotedString)
+	assert.Contains(t, err.Error(), "line 4, column 24")
+}
+
+func TestParseFromString_Toolchain(t *testing.T) {
+	content := `module github.com/example/module
+
+go 1.21.0
+
+toolchain go1.21.5
+`
+	mod, err := ParseFromString(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.21.0", mod.GoVersion)
+	assert.Equal(t, "go1.21.5", mod.Toolchain)
+}
+
+func TestParseFromString_Godebug(t *testing.T) {
+	content := `module github.com/example/module
+
+go 1.23
+
+godebug default=go1.21
+
+godebug (
+	panicnil=1
+	asynctimerchan=0
+)
+`
+	mod, err := ParseFromString(content)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(mod.Godebugs))
+	assert.Equal(t, "default", mod.Godebugs[0].Key)
+	assert.Equal(t, "go1.21", mod.Godebugs[0].Value)
+	assert.Equal(t, "panicnil", mod.Godebugs[1].Key)
+	assert.Equal(t, "1", mod.Godebugs[1].Value)
+	assert.Equal(t, "asynctimerchan", mod.Godebugs[2].Key)
+	assert.Equal(t, "0", mod.Godebugs[2].Value)
+}
+
+func TestParseFromString_Tools(t *testing.T) {
+	content := `module github.com/example/module
+
+go 1.24
+
+tool golang.org/x/tools/cmd/stringer
+
+tool (
+	golang.org/x/vuln/cmd/govulncheck
+	github.com/example/module/cmd/gen
+)
+`
+	mod, err := ParseFromString(content)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(mod.Tools))
+	assert.Equal(t, "golang.org/x/tools/cmd/stringer", mod.Tools[0].Path)
+	assert.Equal(t, "golang.org/x/vuln/cmd/govulncheck", mod.Tools[1].Path)
+	assert.Equal(t, "github.com/example/module/cmd/gen", mod.Tools[2].Path)
+}
+
 func TestParseFromString_SingleRequire(t *testing.T) {
 	content := `module github.com/example/module
 
@@ -111,6 +237,40 @@ replace github.com/old/module => github.com/new/module v1.0.0
 	assert.Equal(t, "v1.0.0", mod.Replaces[0].New.Version)
 }
 
+func TestParseFromString_ReplaceSingleLineForms(t *testing.T) {
+	content := `module github.com/example/module
+
+go 1.21
+
+replace github.com/old/module v1.2.3 => github.com/new/module v1.4.0
+
+replace github.com/local/module => ../local
+`
+	mod, err := ParseFromString(content)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(mod.Replaces))
+	assert.Equal(t, "github.com/old/module", mod.Replaces[0].Old.Path)
+	assert.Equal(t, "v1.2.3", mod.Replaces[0].Old.Version)
+	assert.Equal(t, "github.com/new/module", mod.Replaces[0].New.Path)
+	assert.Equal(t, "v1.4.0", mod.Replaces[0].New.Version)
+	assert.Equal(t, "github.com/local/module", mod.Replaces[1].Old.Path)
+	assert.Equal(t, "../local", mod.Replaces[1].New.Path)
+	assert.Equal(t, "", mod.Replaces[1].New.Version)
+
+	// 单行与块内的replace应得到相同的结果
+	block, err := ParseFromString(`module github.com/example/module
+
+go 1.21
+
+replace (
+	github.com/old/module v1.2.3 => github.com/new/module v1.4.0
+	github.com/local/module => ../local
+)
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, mod.Replaces, block.Replaces)
+}
+
 func TestParseFromString_ReplaceWithVersion(t *testing.T) {
 	content := `module github.com/example/module
 
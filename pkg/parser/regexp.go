@@ -2,20 +2,32 @@ package parser
 
 import "regexp"
 
-// moduleRegexp 匹配module声明
-var moduleRegexp = regexp.MustCompile(`^module\s+([^\s]+)$`)
+// tokenPattern 匹配一个词法单元：带引号的字符串（可以包含空白）或不含空白、不以 // 开头的文本
+const tokenPattern = `("(?:[^"\\]|\\.)*"|` + "`[^`]*`" + `|(?:[^\s/]|/[^\s/])[^\s]*)`
 
-// goRegexp 匹配go版本声明
-var goRegexp = regexp.MustCompile(`^go\s+([^\s]+)$`)
+// moduleRegexp 匹配module声明，允许行尾注释
+var moduleRegexp = regexp.MustCompile(`^module\s+` + tokenPattern + `(?:\s+//.*)?$`)
+
+// goRegexp 匹配go版本声明，允许行尾注释
+var goRegexp = regexp.MustCompile(`^go\s+` + tokenPattern + `(?:\s+//.*)?$`)
+
+// toolchainRegexp 匹配toolchain声明，允许行尾注释
+var toolchainRegexp = regexp.MustCompile(`^toolchain\s+` + tokenPattern + `(?:\s+//.*)?$`)
+
+// singleGodebugRegexp 匹配单行godebug声明
+var singleGodebugRegexp = regexp.MustCompile(`^godebug\s+(.+)$`)
 
 // singleRequireRegexp 匹配单行require声明
-var singleRequireRegexp = regexp.MustCompile(`^require\s+([^\s]+)\s+([^\s]+)(.*)$`)
+var singleRequireRegexp = regexp.MustCompile(`^require\s+` + tokenPattern + `\s+` + tokenPattern + `(.*)$`)
 
-// singleReplaceRegexp 匹配单行replace声明
-var singleReplaceRegexp = regexp.MustCompile(`^replace\s+([^\s]+)\s+=>\s+([^\s]+)\s+([^\s]+)$`)
+// singleToolRegexp 匹配单行tool声明
+var singleToolRegexp = regexp.MustCompile(`^tool\s+(.+)$`)
 
-// singleExcludeRegexp 匹配单行exclude声明
-var singleExcludeRegexp = regexp.MustCompile(`^exclude\s+([^\s]+)\s+([^\s]+)$`)
+// singleReplaceRegexp 匹配单行replace声明，具体的参数由replace块的解析逻辑处理
+var singleReplaceRegexp = regexp.MustCompile(`^replace\s+(.*=>.*)$`)
+
+// singleExcludeRegexp 匹配单行exclude声明，允许行尾注释
+var singleExcludeRegexp = regexp.MustCompile(`^exclude\s+` + tokenPattern + `\s+` + tokenPattern + `(?:\s+//.*)?$`)
 
 // singleRetractVersionRegexp 匹配单行retract声明（单个版本）
 var singleRetractVersionRegexp = regexp.MustCompile(`^retract\s+([^\s\[\]]+)(.*)$`)
@@ -26,5 +38,8 @@ var singleRetractVersionRangeRegexp = regexp.MustCompile(`^retract\s+\[\s*([^\s,
 // indirectCommentRegexp 匹配indirect注释
 var indirectCommentRegexp = regexp.MustCompile(`//\s*indirect`)
 
+// deprecatedRegexp 匹配注释中以 "Deprecated:" 开头的段落
+var deprecatedRegexp = regexp.MustCompile(`(?s)(?:^|\n\n)Deprecated: *(.*?)(?:$|\n\n)`)
+
 // rationaleRegexp 匹配retract理由
 var rationaleRegexp = regexp.MustCompile(`//\s*(.+)`)
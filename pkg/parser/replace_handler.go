@@ -7,17 +7,12 @@ import (
 )
 
 // parseReplaceSingleLine 解析单行replace语句
+// 单行语句支持与replace块完全相同的形式：旧版本可选，文件系统路径的替换目标可以不带版本
 func parseReplaceSingleLine(mod *module.Module, line string) (bool, error) {
-	if matches := singleReplaceRegexp.FindStringSubmatch(line); len(matches) == 4 {
-		mod.Replaces = append(mod.Replaces, &module.Replace{
-			Old: &module.ReplaceItem{
-				Path: matches[1],
-			},
-			New: &module.ReplaceItem{
-				Path:    matches[2],
-				Version: matches[3],
-			},
-		})
+	if matches := singleReplaceRegexp.FindStringSubmatch(line); len(matches) == 2 {
+		if err := parseReplaceBlockLine(mod, matches[1]); err != nil {
+			return false, err
+		}
 		return true, nil
 	}
 	return false, nil
@@ -25,13 +20,34 @@ func parseReplaceSingleLine(mod *module.Module, line string) (bool, error) {
 
 // parseReplaceBlockLine 解析replace块内的语句
 func parseReplaceBlockLine(mod *module.Module, line string) error {
-	parts := strings.Split(line, "=>")
-	if len(parts) != 2 {
+	// 注释中的 => 不是分隔符，先去掉行尾注释
+	fields := withoutComment(splitFields(line))
+	arrow := -1
+	for i, field := range fields {
+		if field != "=>" {
+			continue
+		}
+		if arrow >= 0 {
+			return ErrInvalidReplace
+		}
+		arrow = i
+	}
+	if arrow < 0 {
 		return ErrInvalidReplace
 	}
 
-	oldParts := strings.Fields(strings.TrimSpace(parts[0]))
-	newParts := strings.Fields(strings.TrimSpace(parts[1]))
+	oldParts, err := parseStrings(fields[:arrow]...)
+	if err != nil {
+		return err
+	}
+	newParts, err := parseStrings(fields[arrow+1:]...)
+	if err != nil {
+		return err
+	}
+
+	if len(oldParts) < 1 || len(oldParts) > 2 {
+		return ErrInvalidReplace
+	}
 
 	oldPath := oldParts[0]
 	var oldVersion string
@@ -39,14 +55,20 @@ func parseReplaceBlockLine(mod *module.Module, line string) error {
 		oldVersion = oldParts[1]
 	}
 
-	if len(newParts) < 1 {
+	if len(newParts) < 1 || len(newParts) > 2 {
 		return ErrInvalidReplace
 	}
 
+	// 文件系统路径不能带版本，模块路径必须带版本
 	newPath := newParts[0]
 	var newVersion string
 	if len(newParts) > 1 {
+		if module.IsLocalPath(newPath) {
+			return ErrInvalidReplace
+		}
 		newVersion = newParts[1]
+	} else if !module.IsLocalPath(newPath) {
+		return ErrInvalidReplace
 	}
 
 	mod.Replaces = append(mod.Replaces, &module.Replace{
@@ -61,3 +83,13 @@ func parseReplaceBlockLine(mod *module.Module, line string) error {
 	})
 	return nil
 }
+
+// withoutComment 去掉词法单元列表中从行尾注释开始的部分
+func withoutComment(fields []string) []string {
+	for i, field := range fields {
+		if strings.HasPrefix(field, "//") {
+			return fields[:i]
+		}
+	}
+	return fields
+}
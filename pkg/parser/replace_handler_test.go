@@ -28,6 +28,64 @@ func TestParseReplaceSingleLine(t *testing.T) {
 			expectNewVersion: "v1.0.0",
 			expectError:      false,
 		},
+		{
+			name:             "valid replace with old version",
+			line:             "replace github.com/old/module v1.2.3 => github.com/new/module v1.4.0",
+			expectHandled:    true,
+			expectOldPath:    "github.com/old/module",
+			expectOldVersion: "v1.2.3",
+			expectNewPath:    "github.com/new/module",
+			expectNewVersion: "v1.4.0",
+			expectError:      false,
+		},
+		{
+			name:             "local path replace",
+			line:             "replace github.com/old/module => ../module",
+			expectHandled:    true,
+			expectOldPath:    "github.com/old/module",
+			expectOldVersion: "",
+			expectNewPath:    "../module",
+			expectNewVersion: "",
+			expectError:      false,
+		},
+		{
+			name:             "local path replace with old version and comment",
+			line:             "replace github.com/old/module v1.0.0 => ./fork // temporary fork",
+			expectHandled:    true,
+			expectOldPath:    "github.com/old/module",
+			expectOldVersion: "v1.0.0",
+			expectNewPath:    "./fork",
+			expectNewVersion: "",
+			expectError:      false,
+		},
+		{
+			name:             "arrow in comment",
+			line:             "replace a.com/x => b.com/y v1.0.0 // was a.com/x => c.com/z",
+			expectHandled:    true,
+			expectOldPath:    "a.com/x",
+			expectOldVersion: "",
+			expectNewPath:    "b.com/y",
+			expectNewVersion: "v1.0.0",
+			expectError:      false,
+		},
+		{
+			name:          "module path replacement without version",
+			line:          "replace a.com/x => b.com/y",
+			expectHandled: false,
+			expectError:   true,
+		},
+		{
+			name:          "local path replace with version",
+			line:          "replace github.com/old/module => ../module v1.0.0",
+			expectHandled: false,
+			expectError:   true,
+		},
+		{
+			name:          "too many tokens on old side",
+			line:          "replace github.com/old/module v1.0.0 extra => github.com/new/module v1.0.0",
+			expectHandled: false,
+			expectError:   true,
+		},
 		{
 			name:          "not a replace line",
 			line:          "module github.com/example/module",
@@ -97,19 +155,48 @@ func TestParseReplaceBlockLine(t *testing.T) {
 			expectError:      false,
 		},
 		{
-			name:             "valid replace without new version",
-			line:             "github.com/old/module => github.com/new/module",
+			name:        "module path replacement without version",
+			line:        "github.com/old/module => github.com/new/module",
+			expectError: true,
+		},
+		{
+			name:             "arrow in comment",
+			line:             "github.com/old/module => github.com/new/module v1.0.0 // was github.com/old/module => github.com/other/module",
 			expectOldPath:    "github.com/old/module",
 			expectOldVersion: "",
 			expectNewPath:    "github.com/new/module",
-			expectNewVersion: "",
+			expectNewVersion: "v1.0.0",
 			expectError:      false,
 		},
+		{
+			name:        "arrow only in comment",
+			line:        "github.com/old/module // => github.com/new/module v1.0.0",
+			expectError: true,
+		},
+		{
+			name:        "two arrows",
+			line:        "github.com/old/module => github.com/new/module => github.com/other/module v1.0.0",
+			expectError: true,
+		},
 		{
 			name:        "invalid replace format - missing =>",
 			line:        "github.com/old/module github.com/new/module v1.0.0",
 			expectError: true,
 		},
+		{
+			name:             "absolute path replace",
+			line:             "github.com/old/module => /src/module",
+			expectOldPath:    "github.com/old/module",
+			expectOldVersion: "",
+			expectNewPath:    "/src/module",
+			expectNewVersion: "",
+			expectError:      false,
+		},
+		{
+			name:        "invalid replace format - empty old path",
+			line:        " => github.com/new/module v1.0.0",
+			expectError: true,
+		},
 		{
 			name:        "invalid replace format - empty new path",
 			line:        "github.com/old/module => ",
@@ -15,9 +15,14 @@ func parseRequireSingleLine(mod *module.Module, line string) (bool, error) {
 			indirect = indirectCommentRegexp.MatchString(matches[3])
 		}
 
+		values, err := parseStrings(matches[1], matches[2])
+		if err != nil {
+			return false, err
+		}
+
 		mod.Requires = append(mod.Requires, &module.Require{
-			Path:     matches[1],
-			Version:  matches[2],
+			Path:     values[0],
+			Version:  values[1],
 			Indirect: indirect,
 		})
 		return true, nil
@@ -27,8 +32,8 @@ func parseRequireSingleLine(mod *module.Module, line string) (bool, error) {
 
 // parseRequireBlockLine 解析require块内的语句
 func parseRequireBlockLine(mod *module.Module, line string) error {
-	parts := strings.Fields(line)
-	if len(parts) < 2 {
+	parts := splitFields(line)
+	if len(withoutComment(parts)) < 2 {
 		return ErrInvalidRequire
 	}
 
@@ -42,9 +47,14 @@ func parseRequireBlockLine(mod *module.Module, line string) error {
 		}
 	}
 
+	values, err := parseStrings(parts[0], parts[1])
+	if err != nil {
+		return err
+	}
+
 	mod.Requires = append(mod.Requires, &module.Require{
-		Path:     parts[0],
-		Version:  parts[1],
+		Path:     values[0],
+		Version:  values[1],
 		Indirect: indirect,
 	})
 	return nil
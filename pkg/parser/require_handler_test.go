@@ -35,6 +35,21 @@ func TestParseRequireSingleLine(t *testing.T) {
 			expectIndirect: true,
 			expectError:    false,
 		},
+		{
+			name:           "quoted require",
+			line:           "require \"github.com/example/module\" `v1.0.0` // indirect",
+			expectHandled:  true,
+			expectPath:     "github.com/example/module",
+			expectVersion:  "v1.0.0",
+			expectIndirect: true,
+			expectError:    false,
+		},
+		{
+			name:          "malformed quoted require",
+			line:          "require \"github.com/example/module v1.0.0",
+			expectHandled: false,
+			expectError:   true,
+		},
 		{
 			name:           "valid require with comments",
 			line:           "require github.com/example/module v1.0.0 // some comment",
@@ -10,8 +10,13 @@ import (
 func parseRetractSingleLine(mod *module.Module, line string) (bool, error) {
 	// 首先尝试匹配单个版本的retract
 	if matches := singleRetractVersionRegexp.FindStringSubmatch(line); len(matches) == 3 {
+		version, err := parseString(matches[1])
+		if err != nil {
+			return false, err
+		}
+
 		retract := &module.Retract{
-			Version: matches[1],
+			Version: version,
 		}
 
 		// 检查是否有理由说明
@@ -25,9 +30,14 @@ func parseRetractSingleLine(mod *module.Module, line string) (bool, error) {
 
 	// 然后尝试匹配版本范围的retract
 	if matches := singleRetractVersionRangeRegexp.FindStringSubmatch(line); len(matches) == 4 {
+		versions, err := parseStrings(matches[1], matches[2])
+		if err != nil {
+			return false, err
+		}
+
 		retract := &module.Retract{
-			VersionLow:  matches[1],
-			VersionHigh: matches[2],
+			VersionLow:  versions[0],
+			VersionHigh: versions[1],
 		}
 
 		// 检查是否有理由说明
@@ -60,9 +70,14 @@ func parseRetractBlockLine(mod *module.Module, line string) error {
 			versionRange := line[rangeStart+1 : rangeEnd]
 			versions := strings.Split(versionRange, ",")
 			if len(versions) == 2 {
+				bounds, err := parseStrings(strings.TrimSpace(versions[0]), strings.TrimSpace(versions[1]))
+				if err != nil {
+					return err
+				}
+
 				retract := &module.Retract{
-					VersionLow:  strings.TrimSpace(versions[0]),
-					VersionHigh: strings.TrimSpace(versions[1]),
+					VersionLow:  bounds[0],
+					VersionHigh: bounds[1],
 				}
 
 				// 获取理由（如果有）
@@ -89,13 +104,18 @@ func parseRetractBlockLine(mod *module.Module, line string) error {
 			return ErrInvalidRetract
 		}
 
+		version, err := parseString(parts[0])
+		if err != nil {
+			return err
+		}
+
 		// 检查是否为有效版本号 (简单检查是否以v开头)
-		if len(parts[0]) < 1 || (parts[0][0] != 'v' && parts[0][0] != 'V') {
+		if len(version) < 1 || (version[0] != 'v' && version[0] != 'V') {
 			return ErrInvalidRetract
 		}
 
 		retract := &module.Retract{
-			Version: parts[0],
+			Version: version,
 		}
 
 		// 提取理由（如果有）
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+)
+
+// RetractStatus 描述一个已发布版本的撤回状态
+type RetractStatus struct {
+	// Version 检查的版本
+	Version string
+
+	// Retract 撤回该版本的retract声明，未被撤回时为nil
+	// 多个声明都包含该版本时为其中的第一个
+	Retract *module.Retract
+}
+
+// Retracted 检查版本是否已被撤回
+func (s *RetractStatus) Retracted() bool {
+	return s.Retract != nil
+}
+
+// Rationale 返回撤回理由，未被撤回或声明没有给出理由时返回空字符串
+func (s *RetractStatus) Rationale() string {
+	if s.Retract == nil {
+		return ""
+	}
+	return s.Retract.Rationale
+}
+
+// CheckRetraction 检查模块是否撤回了特定的版本，并给出撤回该版本的retract声明
+func CheckRetraction(mod *module.Module, version string) *RetractStatus {
+	return &RetractStatus{
+		Version: version,
+		Retract: GetRetract(mod, version),
+	}
+}
+
+// CheckRetractions 按照给定的顺序检查每个已发布版本的撤回状态
+// 版本范围按照语义化版本比较，mod通常是模块最新版本的go.mod
+func CheckRetractions(mod *module.Module, versions []string) []*RetractStatus {
+	statuses := make([]*RetractStatus, 0, len(versions))
+	for _, v := range versions {
+		statuses = append(statuses, CheckRetraction(mod, v))
+	}
+	return statuses
+}
+
+// GetRetractedVersions 返回给定版本中已被撤回的版本，保持原有顺序
+func GetRetractedVersions(mod *module.Module, versions []string) []string {
+	retracted := make([]string, 0)
+	for _, v := range versions {
+		if HasRetract(mod, v) {
+			retracted = append(retracted, v)
+		}
+	}
+	return retracted
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRetractions(t *testing.T) {
+	mod, err := ParseFromString(`module github.com/example/module
+
+go 1.21
+
+retract (
+	v0.9.0 // Published too early.
+	[v1.9.0, v1.11.0] // broken cache invalidation
+	v1.10.0 // also covered by the range above
+)
+`)
+	require.NoError(t, err)
+
+	versions := []string{"v0.9.0", "v1.0.0", "v1.9.0", "v1.10.0", "v1.11.0", "v1.12.0", "master"}
+	statuses := CheckRetractions(mod, versions)
+	require.Len(t, statuses, len(versions))
+
+	tests := []struct {
+		retracted bool
+		rationale string
+		retract   *module.Retract
+	}{
+		{true, "Published too early.", mod.Retracts[0]},
+		{false, "", nil},
+		{true, "broken cache invalidation", mod.Retracts[1]},
+		{true, "broken cache invalidation", mod.Retracts[1]}, // 第一个匹配的声明生效
+		{true, "broken cache invalidation", mod.Retracts[1]},
+		{false, "", nil},
+		{false, "", nil},
+	}
+	for i, tt := range tests {
+		status := statuses[i]
+		assert.Equal(t, versions[i], status.Version)
+		assert.Equal(t, tt.retracted, status.Retracted(), versions[i])
+		assert.Equal(t, tt.rationale, status.Rationale(), versions[i])
+		assert.Same(t, tt.retract, status.Retract, versions[i])
+	}
+
+	assert.Equal(t, []string{"v0.9.0", "v1.9.0", "v1.10.0", "v1.11.0"}, GetRetractedVersions(mod, versions))
+	assert.Empty(t, CheckRetractions(mod, nil))
+	assert.Same(t, mod.Retracts[2], GetRetract(&module.Module{Retracts: mod.Retracts[2:]}, "v1.10.0"))
+}
@@ -0,0 +1,188 @@
+package parser
+
+// Position 表示go.mod文件中的一个位置
+type Position struct {
+	// Line 行号，从1开始
+	Line int
+
+	// Column 列号（按字节计算），从1开始
+	Column int
+
+	// Byte 相对文件开头的字节偏移，从0开始
+	Byte int
+}
+
+// Comment 表示一条注释
+type Comment struct {
+	// Start 注释的起始位置
+	Start Position
+
+	// Token 注释原文，包含开头的 //
+	// 块内的空行以Token为空的注释表示，以便格式化时保留
+	Token string
+
+	// Suffix 是否为行尾注释
+	Suffix bool
+}
+
+// Comments 保存附着在语法节点上的注释
+type Comments struct {
+	// Before 紧邻节点之前的整行注释
+	Before []Comment
+
+	// Suffix 与节点同一行的行尾注释
+	Suffix []Comment
+
+	// After 节点之后的注释
+	After []Comment
+}
+
+// Comment 返回节点的注释，便于通过Expr接口统一访问
+func (c *Comments) Comment() *Comments {
+	return c
+}
+
+// Expr 表示语法树中的一个节点
+type Expr interface {
+	// Span 返回节点的起止位置
+	Span() (start, end Position)
+
+	// Comment 返回附着在节点上的注释
+	Comment() *Comments
+}
+
+// FileSyntax 表示go.mod文件的具体语法树，保留了所有注释和位置信息
+type FileSyntax struct {
+	// Name 文件名，可能为空
+	Name string
+
+	// Comments 文件级别的注释
+	Comments
+
+	// Stmt 顶层语句，元素类型为*Line、*LineBlock或*CommentBlock
+	Stmt []Expr
+}
+
+// Span 返回整个文件的起止位置
+func (f *FileSyntax) Span() (start, end Position) {
+	if len(f.Stmt) == 0 {
+		return
+	}
+	start, _ = f.Stmt[0].Span()
+	_, end = f.Stmt[len(f.Stmt)-1].Span()
+	return start, end
+}
+
+// CommentBlock 表示不属于任何语句的独立注释段，与前后语句之间以空行分隔
+type CommentBlock struct {
+	Comments
+
+	// Start 注释段的起始位置
+	Start Position
+}
+
+// Span 返回注释段的起止位置
+func (b *CommentBlock) Span() (start, end Position) {
+	end = b.Start
+	if n := len(b.Before); n > 0 {
+		last := b.Before[n-1]
+		end = last.Start
+		end.Column += len(last.Token)
+		end.Byte += len(last.Token)
+	}
+	return b.Start, end
+}
+
+// Line 表示一条语句，可以是顶层的单行指令，也可以是块内的一行
+type Line struct {
+	Comments
+
+	// Start 语句第一个词法单元的位置
+	Start Position
+
+	// Token 语句的词法单元，带引号的字符串保留原始形式
+	// 顶层语句的第一个词法单元为指令关键字，块内语句不包含关键字
+	Token []string
+
+	// InBlock 语句是否位于块内
+	InBlock bool
+
+	// End 语句最后一个词法单元之后的位置（不含行尾注释）
+	End Position
+
+	// tokenPos 每个词法单元的起始位置，仅对从文本解析得到的语句有效
+	tokenPos []Position
+}
+
+// Span 返回语句的起止位置
+func (l *Line) Span() (start, end Position) {
+	return l.Start, l.End
+}
+
+// TokenPosition 返回第i个词法单元的位置，没有记录位置时返回语句的起始位置
+func (l *Line) TokenPosition(i int) Position {
+	if i >= 0 && i < len(l.tokenPos) {
+		return l.tokenPos[i]
+	}
+	return l.Start
+}
+
+// LineBlock 表示一个块，例如 require ( ... )
+type LineBlock struct {
+	Comments
+
+	// Start 块关键字的位置
+	Start Position
+
+	// LParen 左括号
+	LParen LParen
+
+	// Token 块的关键字，例如 ["require"]
+	Token []string
+
+	// Line 块内的语句
+	Line []*Line
+
+	// RParen 右括号
+	RParen RParen
+}
+
+// Span 返回块的起止位置
+func (b *LineBlock) Span() (start, end Position) {
+	end = b.RParen.Pos
+	end.Column++
+	end.Byte++
+	return b.Start, end
+}
+
+// LParen 表示块的左括号，行尾注释记录在Suffix中
+type LParen struct {
+	Comments
+
+	// Pos 左括号的位置
+	Pos Position
+}
+
+// Span 返回左括号的起止位置
+func (p *LParen) Span() (start, end Position) {
+	end = p.Pos
+	end.Column++
+	end.Byte++
+	return p.Pos, end
+}
+
+// RParen 表示块的右括号，块内最后一行之后的注释记录在Before中
+type RParen struct {
+	Comments
+
+	// Pos 右括号的位置
+	Pos Position
+}
+
+// Span 返回右括号的起止位置
+func (p *RParen) Span() (start, end Position) {
+	end = p.Pos
+	end.Column++
+	end.Byte++
+	return p.Pos, end
+}
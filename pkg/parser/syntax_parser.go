@@ -0,0 +1,240 @@
+package parser
+
+import (
+	"strings"
+)
+
+// parseSyntax 将go.mod文件内容解析为语法树
+// 语法树总是会被构造出来；未闭合的块等结构性错误作为诊断信息一并返回
+func parseSyntax(name string, data []byte) (*FileSyntax, ErrorList) {
+	f := &FileSyntax{Name: name}
+	var errs ErrorList
+
+	// pending 记录尚未附着到语句上的注释
+	var pending []Comment
+	var block *LineBlock
+
+	text := string(data)
+	for lineNum, offset := 1, 0; offset < len(text); lineNum++ {
+		raw := text[offset:]
+		lineStart := offset
+		offset = len(text)
+		if i := strings.IndexByte(raw, '\n'); i >= 0 {
+			raw = raw[:i]
+			offset = lineStart + i + 1
+		}
+		raw = strings.TrimSuffix(raw, "\r")
+
+		tokens, pos, comment := lexLine(raw, lineNum, lineStart)
+
+		// 空行：顶层的空行将之前的注释分隔为独立的注释段，块内的空行予以保留
+		if len(tokens) == 0 && comment == nil {
+			if block != nil {
+				if (len(block.Line) > 0 || len(pending) > 0) && (len(pending) == 0 || pending[len(pending)-1].Token != "") {
+					pending = append(pending, Comment{Start: Position{Line: lineNum, Column: 1, Byte: lineStart}})
+				}
+			} else if len(pending) > 0 {
+				f.Stmt = append(f.Stmt, &CommentBlock{Comments: Comments{Before: pending}, Start: pending[0].Start})
+				pending = nil
+			}
+			continue
+		}
+
+		// 整行注释
+		if len(tokens) == 0 {
+			pending = append(pending, *comment)
+			continue
+		}
+
+		line := &Line{
+			Start:    pos[0],
+			Token:    tokens,
+			End:      lineEnd(tokens, pos),
+			tokenPos: pos,
+		}
+		if comment != nil {
+			comment.Suffix = true
+			line.Suffix = []Comment{*comment}
+		}
+
+		if block != nil {
+			// 块结束
+			if len(tokens) == 1 && tokens[0] == ")" {
+				block.RParen = RParen{
+					Comments: Comments{Before: rparenComments(pending), Suffix: line.Suffix},
+					Pos:      pos[0],
+				}
+				pending = nil
+				block = nil
+				continue
+			}
+
+			line.InBlock = true
+			line.Before = pending
+			pending = nil
+			block.Line = append(block.Line, line)
+			continue
+		}
+
+		// 同一行上闭合的空块，如 require ()
+		if n := len(tokens); n >= 3 && tokens[n-2] == "(" && tokens[n-1] == ")" {
+			f.Stmt = append(f.Stmt, &LineBlock{
+				Comments: Comments{Before: pending},
+				Start:    pos[0],
+				LParen:   LParen{Pos: pos[n-2]},
+				Token:    tokens[:n-2],
+				RParen:   RParen{Comments: Comments{Suffix: line.Suffix}, Pos: pos[n-1]},
+			})
+			pending = nil
+			continue
+		}
+
+		// 块开始
+		if tokens[len(tokens)-1] == "(" {
+			block = &LineBlock{
+				Comments: Comments{Before: pending},
+				Start:    pos[0],
+				LParen:   LParen{Comments: Comments{Suffix: line.Suffix}, Pos: pos[len(pos)-1]},
+				Token:    tokens[:len(tokens)-1],
+			}
+			pending = nil
+			f.Stmt = append(f.Stmt, block)
+			continue
+		}
+
+		line.Before = pending
+		pending = nil
+		f.Stmt = append(f.Stmt, line)
+	}
+
+	if block != nil {
+		// 未闭合的块：将剩余注释保留在块内，并在块开始处报告错误
+		block.RParen.Before = rparenComments(pending)
+		pending = nil
+		errs = append(errs, &ParseError{
+			File:      name,
+			Line:      block.Start.Line,
+			Column:    block.Start.Column,
+			Directive: strings.Join(block.Token, " "),
+			Text:      joinTokens(append(append([]string{}, block.Token...), "(")),
+			Err:       ErrUnclosedBlock,
+		})
+	}
+
+	if len(pending) > 0 {
+		f.Stmt = append(f.Stmt, &CommentBlock{Comments: Comments{Before: pending}, Start: pending[0].Start})
+	}
+
+	return f, errs
+}
+
+// lexLine 将一行文本切分为词法单元和行尾注释
+// 带引号的字符串作为一个整体；( ) [ ] , 和 => 总是单独成为词法单元；
+// 与go命令一样，// 总是开始注释，出现在词法单元中间时结束该词法单元
+func lexLine(raw string, lineNum, lineStart int) ([]string, []Position, *Comment) {
+	var tokens []string
+	var pos []Position
+	var comment *Comment
+
+	i := 0
+	for i < len(raw) {
+		c := raw[i]
+		if isSpace(c) {
+			i++
+			continue
+		}
+
+		start := Position{Line: lineNum, Column: i + 1, Byte: lineStart + i}
+
+		if strings.HasPrefix(raw[i:], "//") {
+			comment = &Comment{Start: start, Token: strings.TrimRight(raw[i:], " \t\r")}
+			break
+		}
+
+		j := i
+		switch {
+		case c == '"':
+			// 解释型字符串，支持反斜杠转义，未闭合时取到行尾
+			j++
+			for j < len(raw) && raw[j] != '"' {
+				if raw[j] == '\\' && j+1 < len(raw) {
+					j++
+				}
+				j++
+			}
+			if j < len(raw) {
+				j++
+			}
+		case c == '`':
+			// 原始字符串，未闭合时取到行尾
+			j++
+			for j < len(raw) && raw[j] != '`' {
+				j++
+			}
+			if j < len(raw) {
+				j++
+			}
+		case strings.HasPrefix(raw[i:], "=>"):
+			j += 2
+		case isPunctuation(c):
+			j++
+		default:
+			for j < len(raw) && !isSpace(raw[j]) && !isPunctuation(raw[j]) &&
+				raw[j] != '"' && raw[j] != '`' && !strings.HasPrefix(raw[j:], "=>") &&
+				!strings.HasPrefix(raw[j:], "//") {
+				j++
+			}
+		}
+
+		tokens = append(tokens, raw[i:j])
+		pos = append(pos, start)
+		i = j
+	}
+
+	return tokens, pos, comment
+}
+
+// isSpace 检查字符是否为空白
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// isPunctuation 检查字符是否总是单独成为词法单元
+func isPunctuation(c byte) bool {
+	return c == '(' || c == ')' || c == '[' || c == ']' || c == ','
+}
+
+// lineEnd 计算语句最后一个词法单元之后的位置
+func lineEnd(tokens []string, pos []Position) Position {
+	last := pos[len(pos)-1]
+	n := len(tokens[len(tokens)-1])
+	return Position{Line: last.Line, Column: last.Column + n, Byte: last.Byte + n}
+}
+
+// rparenComments 返回右括号之前的注释
+// 与go命令一样，只有一个空行时不保留，其余情况下末尾的空行随注释一起保留
+func rparenComments(comments []Comment) []Comment {
+	if len(comments) == 1 && comments[0].Token == "" {
+		return nil
+	}
+	return comments
+}
+
+// joinTokens 按照go.mod的书写习惯拼接词法单元
+// 词法单元之间以一个空格分隔，但 [ ( 之后以及 , ] ) 之前不加空格
+func joinTokens(tokens []string) string {
+	var b strings.Builder
+	sep := ""
+	for _, token := range tokens {
+		if token == "," || token == ")" || token == "]" {
+			sep = ""
+		}
+		b.WriteString(sep)
+		b.WriteString(token)
+		sep = " "
+		if token == "(" || token == "[" {
+			sep = ""
+		}
+	}
+	return b.String()
+}
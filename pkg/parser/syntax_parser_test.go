@@ -0,0 +1,230 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexLine(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		expectTokens  []string
+		expectColumns []int
+		expectComment string
+	}{
+		{
+			name:          "simple directive",
+			line:          "require github.com/example/module v1.0.0",
+			expectTokens:  []string{"require", "github.com/example/module", "v1.0.0"},
+			expectColumns: []int{1, 9, 35},
+		},
+		{
+			name:          "trailing comment",
+			line:          "\tgithub.com/example/module v1.0.0 // indirect",
+			expectTokens:  []string{"github.com/example/module", "v1.0.0"},
+			expectColumns: []int{2, 28},
+			expectComment: "// indirect",
+		},
+		{
+			name:          "comment only",
+			line:          "  // just a comment  ",
+			expectComment: "// just a comment",
+		},
+		{
+			name:          "double slash ends token",
+			line:          "module github.com//foo",
+			expectTokens:  []string{"module", "github.com"},
+			expectColumns: []int{1, 8},
+			expectComment: "//foo",
+		},
+		{
+			name:          "comment without space",
+			line:          "require example.com/x v1.0.0//indirect",
+			expectTokens:  []string{"require", "example.com/x", "v1.0.0"},
+			expectColumns: []int{1, 9, 23},
+			expectComment: "//indirect",
+		},
+		{
+			name:          "quoted strings",
+			line:          "require \"example.com/a b\" `v1.0.0`",
+			expectTokens:  []string{"require", `"example.com/a b"`, "`v1.0.0`"},
+			expectColumns: []int{1, 9, 27},
+		},
+		{
+			name:          "escaped quote",
+			line:          `module "a\"b" // c`,
+			expectTokens:  []string{"module", `"a\"b"`},
+			expectColumns: []int{1, 8},
+			expectComment: "// c",
+		},
+		{
+			name:          "unterminated quote",
+			line:          `require "example.com/x v1.0.0`,
+			expectTokens:  []string{"require", `"example.com/x v1.0.0`},
+			expectColumns: []int{1, 9},
+		},
+		{
+			name:          "punctuation",
+			line:          "retract [v1.0.0,v1.0.5]",
+			expectTokens:  []string{"retract", "[", "v1.0.0", ",", "v1.0.5", "]"},
+			expectColumns: []int{1, 9, 10, 16, 17, 23},
+		},
+		{
+			name:          "arrow",
+			line:          "replace a=>b v1.0.0",
+			expectTokens:  []string{"replace", "a", "=>", "b", "v1.0.0"},
+			expectColumns: []int{1, 9, 10, 12, 14},
+		},
+		{
+			name:          "block start",
+			line:          "require (",
+			expectTokens:  []string{"require", "("},
+			expectColumns: []int{1, 9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, pos, comment := lexLine(tt.line, 3, 100)
+			assert.Equal(t, tt.expectTokens, tokens)
+
+			columns := make([]int, 0, len(pos))
+			for i, p := range pos {
+				assert.Equal(t, 3, p.Line)
+				assert.Equal(t, 100+p.Column-1, p.Byte)
+				columns = append(columns, pos[i].Column)
+			}
+			if tt.expectColumns == nil {
+				assert.Empty(t, columns)
+			} else {
+				assert.Equal(t, tt.expectColumns, columns)
+			}
+
+			if tt.expectComment == "" {
+				assert.Nil(t, comment)
+			} else {
+				require.NotNil(t, comment)
+				assert.Equal(t, tt.expectComment, comment.Token)
+			}
+		})
+	}
+}
+
+func TestJoinTokens(t *testing.T) {
+	assert.Equal(t, "require github.com/x v1.0.0", joinTokens([]string{"require", "github.com/x", "v1.0.0"}))
+	assert.Equal(t, "retract [v1.0.0, v1.0.5]", joinTokens([]string{"retract", "[", "v1.0.0", ",", "v1.0.5", "]"}))
+	assert.Equal(t, "require (", joinTokens([]string{"require", "("}))
+	assert.Equal(t, "", joinTokens(nil))
+}
+
+func TestParseSyntax(t *testing.T) {
+	content := `// header comment
+
+// Deprecated: use v2
+module github.com/example/module // trailing
+
+go 1.21
+
+require ( // direct deps
+	// testify for tests
+	github.com/stretchr/testify v1.8.4
+
+	github.com/pkg/errors v0.9.1 // indirect
+	// end of block
+) // closing
+
+// footer
+`
+	f, errs := parseSyntax("go.mod", []byte(content))
+	require.Empty(t, errs)
+	assert.Equal(t, "go.mod", f.Name)
+	require.Len(t, f.Stmt, 5)
+
+	header, ok := f.Stmt[0].(*CommentBlock)
+	require.True(t, ok)
+	assert.Equal(t, []string{"// header comment"}, commentTokens(header.Before))
+	assert.Equal(t, Position{Line: 1, Column: 1, Byte: 0}, header.Start)
+
+	mod, ok := f.Stmt[1].(*Line)
+	require.True(t, ok)
+	assert.Equal(t, []string{"module", "github.com/example/module"}, mod.Token)
+	assert.Equal(t, []string{"// Deprecated: use v2"}, commentTokens(mod.Before))
+	require.Len(t, mod.Suffix, 1)
+	assert.Equal(t, "// trailing", mod.Suffix[0].Token)
+	assert.True(t, mod.Suffix[0].Suffix)
+	start, end := mod.Span()
+	assert.Equal(t, Position{Line: 4, Column: 1, Byte: 41}, start)
+	assert.Equal(t, Position{Line: 4, Column: 33, Byte: 73}, end)
+
+	goLine, ok := f.Stmt[2].(*Line)
+	require.True(t, ok)
+	assert.Equal(t, []string{"go", "1.21"}, goLine.Token)
+	assert.Empty(t, goLine.Before)
+
+	block, ok := f.Stmt[3].(*LineBlock)
+	require.True(t, ok)
+	assert.Equal(t, []string{"require"}, block.Token)
+	assert.Equal(t, "// direct deps", block.LParen.Suffix[0].Token)
+	require.Len(t, block.Line, 2)
+	assert.True(t, block.Line[0].InBlock)
+	assert.Equal(t, []string{"github.com/stretchr/testify", "v1.8.4"}, block.Line[0].Token)
+	assert.Equal(t, []string{"// testify for tests"}, commentTokens(block.Line[0].Before))
+	assert.Equal(t, 2, block.Line[0].Start.Column)
+
+	// 块内的空行以空注释保留
+	require.Len(t, block.Line[1].Before, 1)
+	assert.Equal(t, "", block.Line[1].Before[0].Token)
+	assert.Equal(t, "// indirect", block.Line[1].Suffix[0].Token)
+	assert.Equal(t, []string{"// end of block"}, commentTokens(block.RParen.Before))
+	assert.Equal(t, "// closing", block.RParen.Suffix[0].Token)
+	assert.Equal(t, 14, block.RParen.Pos.Line)
+
+	footer, ok := f.Stmt[4].(*CommentBlock)
+	require.True(t, ok)
+	assert.Equal(t, []string{"// footer"}, commentTokens(footer.Before))
+}
+
+func TestParseSyntax_UnclosedBlock(t *testing.T) {
+	content := "module github.com/example/module\n\nrequire (\n\tgithub.com/pkg/errors v0.9.1\n"
+	f, errs := parseSyntax("", []byte(content))
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrUnclosedBlock)
+	assert.Equal(t, 3, errs[0].Line)
+
+	// 未闭合的块仍然保留在语法树中
+	require.Len(t, f.Stmt, 2)
+	block, ok := f.Stmt[1].(*LineBlock)
+	require.True(t, ok)
+	assert.Len(t, block.Line, 1)
+}
+
+func TestParseSyntax_EmptyBlockOnOneLine(t *testing.T) {
+	content := "module github.com/example/module\n\nrequire () // none\n\ngo 1.21\n"
+	f, errs := parseSyntax("", []byte(content))
+	require.Empty(t, errs)
+	require.Len(t, f.Stmt, 3)
+
+	block, ok := f.Stmt[1].(*LineBlock)
+	require.True(t, ok)
+	assert.Equal(t, []string{"require"}, block.Token)
+	assert.Empty(t, block.Line)
+	assert.Equal(t, 9, block.LParen.Pos.Column)
+	assert.Equal(t, 10, block.RParen.Pos.Column)
+	assert.Equal(t, []string{"// none"}, commentTokens(block.RParen.Suffix))
+	assert.Equal(t, []string{"go", "1.21"}, f.Stmt[2].(*Line).Token)
+
+	mod, err := ParseFromString(content)
+	require.NoError(t, err)
+	assert.Empty(t, mod.Requires)
+	assert.Equal(t, "1.21", mod.GoVersion)
+}
+
+func TestParseSyntax_CRLF(t *testing.T) {
+	f, errs := parseSyntax("", []byte("module github.com/example/module\r\n\r\ngo 1.21\r\n"))
+	require.Empty(t, errs)
+	require.Len(t, f.Stmt, 2)
+	assert.Equal(t, []string{"go", "1.21"}, f.Stmt[1].(*Line).Token)
+}
@@ -0,0 +1,33 @@
+package parser
+
+import "github.com/scagogogo/go-mod-parser/pkg/module"
+
+// parseToolSingleLine 解析单行tool语句
+func parseToolSingleLine(mod *module.Module, line string) (bool, error) {
+	if matches := singleToolRegexp.FindStringSubmatch(line); len(matches) == 2 {
+		if err := parseToolBlockLine(mod, matches[1]); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// parseToolBlockLine 解析tool块内的语句
+func parseToolBlockLine(mod *module.Module, line string) error {
+	// 工具只有一个路径参数，允许行尾注释
+	parts := withoutComment(splitFields(line))
+	if len(parts) != 1 {
+		return ErrInvalidTool
+	}
+
+	path, err := parseString(parts[0])
+	if err != nil {
+		return err
+	}
+
+	mod.Tools = append(mod.Tools, &module.Tool{
+		Path: path,
+	})
+	return nil
+}
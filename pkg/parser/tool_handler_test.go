@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseToolSingleLine(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		expectHandled bool
+		expectPath    string
+		expectError   bool
+	}{
+		{
+			name:          "valid tool",
+			line:          "tool golang.org/x/tools/cmd/stringer",
+			expectHandled: true,
+			expectPath:    "golang.org/x/tools/cmd/stringer",
+			expectError:   false,
+		},
+		{
+			name:          "tool with trailing comment",
+			line:          "tool golang.org/x/tools/cmd/stringer // codegen",
+			expectHandled: true,
+			expectPath:    "golang.org/x/tools/cmd/stringer",
+			expectError:   false,
+		},
+		{
+			name:          "not a tool line",
+			line:          "toolchain go1.24.0",
+			expectHandled: false,
+			expectError:   false,
+		},
+		{
+			name:          "extra tokens",
+			line:          "tool golang.org/x/tools/cmd/stringer v0.1.0",
+			expectHandled: false,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mod := &module.Module{}
+			handled, err := parseToolSingleLine(mod, tt.line)
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrInvalidTool)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectHandled, handled)
+
+			if tt.expectHandled {
+				assert.Equal(t, 1, len(mod.Tools))
+				assert.Equal(t, tt.expectPath, mod.Tools[0].Path)
+			} else {
+				assert.Equal(t, 0, len(mod.Tools))
+			}
+		})
+	}
+}
+
+func TestParseToolBlockLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		expectPath  string
+		expectError bool
+	}{
+		{
+			name:        "valid tool in block",
+			line:        "golang.org/x/vuln/cmd/govulncheck",
+			expectPath:  "golang.org/x/vuln/cmd/govulncheck",
+			expectError: false,
+		},
+		{
+			name:        "empty line",
+			line:        "",
+			expectError: true,
+		},
+		{
+			name:        "extra tokens",
+			line:        "golang.org/x/vuln/cmd/govulncheck latest",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mod := &module.Module{}
+			err := parseToolBlockLine(mod, tt.line)
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrInvalidTool)
+				assert.Equal(t, 0, len(mod.Tools))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, len(mod.Tools))
+				assert.Equal(t, tt.expectPath, mod.Tools[0].Path)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+)
+
+// Validate 对解析得到的模块进行语义检查，返回定位到具体词法单元的ErrorList，没有问题时返回nil
+// 检查内容包括：module、require、exclude、replace和tool中的路径是否符合Go的路径规则
+// （依赖的模块路径必须以域名开头，主模块路径、replace路径和工具路径按导入路径检查），
+// 模块路径的主版本后缀是否合法，以及require、exclude、replace中的版本号
+// 和retract中撤回的版本号是否与对应模块路径的主版本后缀一致（包括+incompatible的使用）
+func (f *File) Validate() error {
+	errs := f.validate()
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validate 进行语义检查，诊断信息按行号排序
+func (f *File) validate() ErrorList {
+	mod := f.Module
+	var errs ErrorList
+
+	validName := false
+	if mod.Name != "" {
+		if err := module.CheckImportPath(mod.Name); err != nil {
+			errs = append(errs, f.entryError("module", "module", mod.Name, err))
+		} else if _, _, ok := module.SplitPathVersion(mod.Name); !ok {
+			err := fmt.Errorf("%w: %s", module.ErrInvalidMajorSuffix, mod.Name)
+			errs = append(errs, f.entryError("module", "module", mod.Name, err))
+		} else {
+			validName = true
+		}
+	}
+
+	for _, req := range mod.Requires {
+		errs = f.checkModule(errs, req, "require", req.Path, req.Version, module.CheckPath)
+	}
+	for _, exc := range mod.Excludes {
+		errs = f.checkModule(errs, exc, "exclude", exc.Path, exc.Version, module.CheckPath)
+	}
+	for _, rep := range mod.Replaces {
+		errs = f.checkModule(errs, rep, "replace", rep.Old.Path, rep.Old.Version, module.CheckImportPath)
+		if !rep.IsLocal() {
+			errs = f.checkModule(errs, rep, "replace", rep.New.Path, rep.New.Version, module.CheckImportPath)
+		}
+	}
+	for _, tool := range mod.Tools {
+		if err := module.CheckImportPath(tool.Path); err != nil {
+			errs = append(errs, f.entryError(tool, "tool", tool.Path, err))
+		}
+	}
+	if validName {
+		// retract撤回的是当前模块自身的版本，与module声明的主版本后缀比较
+		for _, ret := range mod.Retracts {
+			for _, version := range []string{ret.Version, ret.VersionLow, ret.VersionHigh} {
+				if version != "" {
+					errs = f.checkPathMajor(errs, ret, "retract", mod.Name, version)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Line < errs[j].Line
+	})
+	return errs
+}
+
+// checkModule 使用checkPath检查模块路径，路径合法时再检查版本号与主版本后缀是否一致
+// 路径不合法时只报告路径的问题
+func (f *File) checkModule(errs ErrorList, entry any, directive, path, version string, checkPath func(string) error) ErrorList {
+	if err := checkPath(path); err != nil {
+		return append(errs, f.entryError(entry, directive, path, err))
+	}
+	return f.checkPathMajor(errs, entry, directive, path, version)
+}
+
+// checkPathMajor 检查版本号与模块路径的主版本后缀是否一致，不一致时追加诊断信息
+// 主版本后缀本身不合法时定位到模块路径，否则定位到版本号
+func (f *File) checkPathMajor(errs ErrorList, entry any, directive, path, version string) ErrorList {
+	err := module.CheckPathMajor(version, path)
+	if err == nil {
+		return errs
+	}
+	value := version
+	if errors.Is(err, module.ErrInvalidMajorSuffix) {
+		value = path
+	}
+	return append(errs, f.entryError(entry, directive, value, err))
+}
+
+// entryError 构造与模块信息中某一项相关的诊断信息，定位到值为value的词法单元
+func (f *File) entryError(entry any, directive, value string, err error) *ParseError {
+	line := f.Line(entry)
+	if line == nil {
+		return &ParseError{File: f.Syntax.Name, Directive: directive, Text: value, Err: err}
+	}
+
+	var parseErr *ParseError
+	for _, token := range line.Token {
+		if v, unquoteErr := parseString(token); unquoteErr == nil && v == value {
+			parseErr = lineParseError(line, directive, &tokenError{token: token, err: err})
+			break
+		}
+	}
+	if parseErr == nil {
+		parseErr = lineParseError(line, directive, err)
+	}
+	parseErr.File = f.Syntax.Name
+	return parseErr
+}
@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_Validate(t *testing.T) {
+	content := `module github.com/example/module/v2
+
+require (
+	github.com/docker/docker v24.0.7+incompatible
+	github.com/example/lib/v3 v3.1.0
+	github.com/example/old v2.0.0
+	github.com/example/lib/v4 v4.0.0+incompatible
+	gopkg.in/yaml.v3 v2.4.0
+	github.com/example/bad/v1 v1.0.0
+)
+
+exclude github.com/example/plain v1.0.0+incompatible
+
+replace github.com/example/lib/v3 v3.1.0 => github.com/fork/lib/v3 v2.0.0
+
+replace github.com/example/local/v2 => ../local
+
+retract [v2.0.0, v2.0.5]
+
+retract v1.0.0 // wrong major version
+`
+	f, err := ParseSyntaxFromString(content)
+	require.NoError(t, err)
+
+	err = f.Validate()
+	require.Error(t, err)
+
+	var list ErrorList
+	require.True(t, errors.As(err, &list))
+
+	expected := []struct {
+		line   int
+		column int
+		text   string
+		err    error
+	}{
+		{6, 25, "v2.0.0", module.ErrMajorVersionMismatch},
+		{7, 28, "v4.0.0+incompatible", module.ErrInvalidIncompatible},
+		{8, 19, "v2.4.0", module.ErrMajorVersionMismatch},
+		{9, 2, "github.com/example/bad/v1", module.ErrInvalidMajorSuffix},
+		{12, 34, "v1.0.0+incompatible", module.ErrInvalidIncompatible},
+		{14, 68, "v2.0.0", module.ErrMajorVersionMismatch},
+		{20, 9, "v1.0.0", module.ErrMajorVersionMismatch},
+	}
+	require.Len(t, list, len(expected))
+	for i, want := range expected {
+		assert.Equal(t, want.line, list[i].Line, "error %d", i)
+		assert.Equal(t, want.column, list[i].Column, "error %d", i)
+		assert.Equal(t, want.text, list[i].Text, "error %d", i)
+		assert.ErrorIs(t, list[i], want.err, "error %d", i)
+	}
+	assert.Equal(t, "require", list[0].Directive)
+	assert.Equal(t, "retract", list[len(list)-1].Directive)
+}
+
+func TestFile_Validate_Valid(t *testing.T) {
+	f, err := ParseSyntaxFromString(`module github.com/example/module
+
+require (
+	github.com/docker/docker v24.0.7+incompatible
+	github.com/example/lib/v2 v2.0.0-20231010123456-abcdef123456
+	gopkg.in/check.v1 v0.0.0-20161208181325-20d25e280405
+)
+
+replace github.com/example/lib/v2 => ../lib
+`)
+	require.NoError(t, err)
+	assert.NoError(t, f.Validate())
+}
+
+func TestFile_Validate_InvalidModulePath(t *testing.T) {
+	f, err := ParseSyntaxFromString("module github.com/example/module/v1\n\nretract v1.0.0\n")
+	require.NoError(t, err)
+
+	var list ErrorList
+	require.True(t, errors.As(f.Validate(), &list))
+	require.Len(t, list, 1)
+	assert.Equal(t, 1, list[0].Line)
+	assert.Equal(t, 8, list[0].Column)
+	assert.ErrorIs(t, list[0], module.ErrInvalidMajorSuffix)
+}
+
+func TestParseWithOptions_Validate(t *testing.T) {
+	content := "module github.com/example/module\n\nrequire github.com/example/lib v2.0.0\n"
+
+	// 不开启Validate时只检查语法
+	mod, err := ParseWithOptions(strings.NewReader(content), Options{})
+	require.NoError(t, err)
+	assert.Len(t, mod.Requires, 1)
+
+	mod, err = ParseWithOptions(strings.NewReader(content), Options{FileName: "go.mod", Validate: true})
+	assert.Nil(t, mod)
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, "go.mod", parseErr.File)
+	assert.Equal(t, 3, parseErr.Line)
+	assert.Equal(t, 32, parseErr.Column)
+	assert.ErrorIs(t, err, module.ErrMajorVersionMismatch)
+
+	// 恢复模式下语法错误和语义问题一并返回
+	content += "\ninvalid line\n"
+	mod, err = ParseWithOptions(strings.NewReader(content), Options{Recover: true, Validate: true})
+	require.NotNil(t, mod)
+	var list ErrorList
+	require.True(t, errors.As(err, &list))
+	require.Len(t, list, 2)
+	assert.ErrorIs(t, list[0], module.ErrMajorVersionMismatch)
+	assert.ErrorIs(t, list[1], ErrUnrecognizedLine)
+}
+
+func TestFile_Validate_Paths(t *testing.T) {
+	content := `module "my module"
+
+require (
+	github.com/foo/ v1.0.0
+	GITHUB.COM/example/lib v1.0.0
+	internal/lib v1.0.0
+	github.com/example/ok v2.0.0
+)
+
+exclude github.com/example/con v1.0.0
+
+replace example.local/x => github.com/fork/x. v1.0.0
+
+tool github.com/example/tool/.cmd
+tool github.com/example/tool/nul
+`
+	f, err := ParseSyntaxFromString(content)
+	require.NoError(t, err)
+
+	var list ErrorList
+	require.True(t, errors.As(f.Validate(), &list))
+
+	expected := []struct {
+		line   int
+		column int
+		text   string
+		err    error
+	}{
+		{1, 8, `"my module"`, module.ErrMalformedImportPath},
+		{4, 2, "github.com/foo/", module.ErrMalformedModulePath},
+		{5, 2, "GITHUB.COM/example/lib", module.ErrMalformedModulePath},
+		{6, 2, "internal/lib", module.ErrMalformedModulePath},
+		{7, 24, "v2.0.0", module.ErrMajorVersionMismatch},
+		{10, 9, "github.com/example/con", module.ErrMalformedModulePath},
+		{12, 28, "github.com/fork/x.", module.ErrMalformedImportPath},
+		{15, 6, "github.com/example/tool/nul", module.ErrMalformedImportPath},
+	}
+	require.Len(t, list, len(expected))
+	for i, want := range expected {
+		assert.Equal(t, want.line, list[i].Line, "error %d", i)
+		assert.Equal(t, want.column, list[i].Column, "error %d", i)
+		assert.Equal(t, want.text, list[i].Text, "error %d", i)
+		assert.ErrorIs(t, list[i], want.err, "error %d", i)
+	}
+	assert.Equal(t, "tool", list[len(list)-1].Directive)
+}
@@ -0,0 +1,224 @@
+// Package query 按照go命令的规则，在给定的版本列表中解析模块版本查询
+//
+// 支持的查询形式与 go get 相同：
+//
+//	latest              最新的正式版本，没有正式版本时为最新的预发布版本
+//	upgrade             与latest相同，但当前版本更高时保留当前版本
+//	patch               与当前版本主版本号、次版本号相同的最新版本，不会降级
+//	v1.2.3              指定的版本
+//	v1、v1.2            具有该前缀的最新版本
+//	>v1.2.0、>=v1.2.0   满足条件的最低版本
+//	<v2、<=v1.5.0       满足条件的最高版本
+//	master、abcdef1     分支名或提交哈希前缀：列表中同名的版本，或提交哈希以其开头的伪版本
+//
+// 与go命令一致，正式版本总是优先于预发布版本：只有没有满足条件的正式版本时才会选择预发布版本。
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+	"github.com/scagogogo/go-mod-parser/pkg/parser"
+	"github.com/scagogogo/go-mod-parser/pkg/semver"
+)
+
+var (
+	// ErrInvalidQuery 表示无法识别的版本查询
+	ErrInvalidQuery = errors.New("invalid version query")
+	// ErrNoMatchingVersion 表示没有满足查询条件的版本
+	ErrNoMatchingVersion = errors.New("no matching versions")
+)
+
+// Options 控制版本查询的解析
+type Options struct {
+	// Current 当前使用的版本，upgrade和patch查询以它为基准；为空时两者都等同于latest
+	Current string
+
+	// Module 主模块：其中针对被查询路径的exclude声明所排除的版本会被跳过，
+	// 被查询的是主模块自身时，其retract声明撤回的版本也会被跳过。可以为nil
+	Module *module.Module
+
+	// Retracts 被查询模块自身go.mod中的retract声明（通常取自其最新版本），撤回的版本会被跳过
+	Retracts []*module.Retract
+}
+
+// Resolve 在versions中解析模块path的版本查询，返回选中的版本
+// 被排除或撤回的版本不会被选中；明确指定的版本即使已撤回也可以选中，但被排除时不能选中
+func Resolve(path, query string, versions []string, opts Options) (string, error) {
+	r := &resolver{path: path, opts: opts}
+
+	var v string
+	switch {
+	case query == "latest":
+		v = r.pick(versions, func(string) bool { return true }, true)
+
+	case query == "upgrade":
+		v = r.noDowngrade(r.pick(versions, func(string) bool { return true }, true))
+
+	case query == "patch":
+		if !semver.IsValid(opts.Current) {
+			return Resolve(path, "latest", versions, opts)
+		}
+		majorMinor := semver.MajorMinor(opts.Current)
+		v = r.noDowngrade(r.pick(versions, func(v string) bool {
+			return semver.MajorMinor(v) == majorMinor
+		}, true))
+
+	case strings.HasPrefix(query, "<") || strings.HasPrefix(query, ">"):
+		match, highest, err := comparison(query)
+		if err != nil {
+			return "", err
+		}
+		v = r.pick(versions, match, highest)
+
+	case semver.IsValid(query) && isVersionPrefix(query):
+		v = r.pick(versions, func(v string) bool {
+			return v == query || strings.HasPrefix(v, query+".")
+		}, true)
+
+	case semver.IsValid(query):
+		v = r.exact(versions, query)
+
+	case query == "":
+		return "", fmt.Errorf("%w: empty query", ErrInvalidQuery)
+
+	default:
+		v = r.revision(versions, query)
+	}
+
+	if v == "" {
+		return "", fmt.Errorf("%w for %s@%s", ErrNoMatchingVersion, path, query)
+	}
+	return v, nil
+}
+
+// ResolveRequire 解析主模块中某个依赖的版本查询，以依赖当前的版本作为upgrade和patch的基准
+func ResolveRequire(mod *module.Module, req *module.Require, query string, versions []string) (string, error) {
+	return Resolve(req.Path, query, versions, Options{Current: req.Version, Module: mod})
+}
+
+// resolver 保存一次查询的上下文
+type resolver struct {
+	path string
+	opts Options
+}
+
+// allowed 检查版本是否可以被选中：没有被主模块排除，也没有被撤回
+func (r *resolver) allowed(v string) bool {
+	return !r.excluded(v) && !r.retracted(v)
+}
+
+// excluded 检查版本是否被主模块的exclude声明排除
+func (r *resolver) excluded(v string) bool {
+	return r.opts.Module != nil && parser.HasExclude(r.opts.Module, r.path, v)
+}
+
+// retracted 检查版本是否被撤回
+func (r *resolver) retracted(v string) bool {
+	if r.opts.Module != nil && r.opts.Module.Name == r.path && parser.HasRetract(r.opts.Module, v) {
+		return true
+	}
+	return len(r.opts.Retracts) > 0 && parser.HasRetract(&module.Module{Retracts: r.opts.Retracts}, v)
+}
+
+// pick 在满足match且允许选中的版本中选出最高（highest为true）或最低的版本
+// 正式版本优先于预发布版本；伪版本和不合法的版本不参与选择
+func (r *resolver) pick(versions []string, match func(string) bool, highest bool) string {
+	var release, prerelease string
+	better := func(v, best string) bool {
+		if best == "" {
+			return true
+		}
+		c := semver.Compare(v, best)
+		return highest && c > 0 || !highest && c < 0
+	}
+
+	for _, v := range versions {
+		if !semver.IsValid(v) || semver.IsPseudoVersion(v) || !match(v) || !r.allowed(v) {
+			continue
+		}
+		if semver.Prerelease(v) == "" {
+			if better(v, release) {
+				release = v
+			}
+		} else if better(v, prerelease) {
+			prerelease = v
+		}
+	}
+
+	if release != "" {
+		return release
+	}
+	return prerelease
+}
+
+// noDowngrade 当前版本高于选中的版本（或没有选中版本）时保留当前版本
+func (r *resolver) noDowngrade(v string) string {
+	current := r.opts.Current
+	if semver.IsValid(current) && (v == "" || semver.Compare(current, v) > 0) {
+		return current
+	}
+	return v
+}
+
+// exact 查找指定的版本，被排除的版本不能选中
+func (r *resolver) exact(versions []string, query string) string {
+	for _, v := range versions {
+		if v == query && !r.excluded(v) {
+			return v
+		}
+	}
+	return ""
+}
+
+// revision 按分支名或提交哈希前缀查找：优先返回列表中同名的版本，
+// 否则返回提交哈希以query开头的最高伪版本
+func (r *resolver) revision(versions []string, query string) string {
+	for _, v := range versions {
+		if v == query && !r.excluded(v) {
+			return v
+		}
+	}
+
+	var best string
+	for _, v := range versions {
+		p, err := semver.ParsePseudoVersion(v)
+		if err != nil || !strings.HasPrefix(p.Revision, query) || !r.allowed(v) {
+			continue
+		}
+		if best == "" || semver.Compare(v, best) > 0 {
+			best = v
+		}
+	}
+	return best
+}
+
+// comparison 解析 <、<=、>、>= 形式的查询，返回匹配函数以及是否选择最高版本
+func comparison(query string) (match func(string) bool, highest bool, err error) {
+	op := query[:1]
+	if strings.HasPrefix(query[1:], "=") {
+		op = query[:2]
+	}
+	bound := query[len(op):]
+	if !semver.IsValid(bound) {
+		return nil, false, fmt.Errorf("%w: %q", ErrInvalidQuery, query)
+	}
+
+	switch op {
+	case "<":
+		return func(v string) bool { return semver.Compare(v, bound) < 0 }, true, nil
+	case "<=":
+		return func(v string) bool { return semver.Compare(v, bound) <= 0 }, true, nil
+	case ">":
+		return func(v string) bool { return semver.Compare(v, bound) > 0 }, false, nil
+	default:
+		return func(v string) bool { return semver.Compare(v, bound) >= 0 }, false, nil
+	}
+}
+
+// isVersionPrefix 检查合法的版本号是否为 v1、v1.2 这样的简写形式
+func isVersionPrefix(v string) bool {
+	return strings.Count(v, ".") < 2
+}
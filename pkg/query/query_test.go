@@ -0,0 +1,152 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/scagogogo/go-mod-parser/pkg/module"
+)
+
+var testVersions = []string{
+	"v1.0.0",
+	"v1.2.0",
+	"v1.2.1",
+	"v1.2.2",
+	"v1.3.0-rc.1",
+	"v1.9.0",
+	"v1.10.0",
+	"v1.10.1-beta",
+	"v2.0.0+incompatible",
+	"v1.10.2-0.20231010123456-abcdef123456",
+	"master",
+	"not-a-version",
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		opts     Options
+		expected string
+	}{
+		{"latest prefers releases", "latest", Options{}, "v2.0.0+incompatible"},
+		{"upgrade", "upgrade", Options{Current: "v1.2.0"}, "v2.0.0+incompatible"},
+		{"upgrade keeps newer current", "upgrade", Options{Current: "v3.0.0-pre"}, "v3.0.0-pre"},
+		{"upgrade without current", "upgrade", Options{}, "v2.0.0+incompatible"},
+		{"patch", "patch", Options{Current: "v1.2.0"}, "v1.2.2"},
+		{"patch semantic minor", "patch", Options{Current: "v1.10.0"}, "v1.10.0"},
+		{"patch never downgrades", "patch", Options{Current: "v1.2.5"}, "v1.2.5"},
+		{"patch without current", "patch", Options{}, "v2.0.0+incompatible"},
+		{"greater or equal picks lowest", ">=v1.2.0", Options{}, "v1.2.0"},
+		{"greater picks lowest", ">v1.2.2", Options{}, "v1.9.0"},
+		{"greater falls back to prerelease", ">v2.0.0", Options{}, ""},
+		{"less picks highest release", "<v2", Options{}, "v1.10.0"},
+		{"less prefers release over closer prerelease", "<v1.3.0", Options{}, "v1.2.2"},
+		{"less or equal", "<=v1.9.0", Options{}, "v1.9.0"},
+		{"prefix major", "v1", Options{}, "v1.10.0"},
+		{"prefix minor", "v1.2", Options{}, "v1.2.2"},
+		{"prefix only prerelease", "v1.3", Options{}, "v1.3.0-rc.1"},
+		{"exact", "v1.2.1", Options{}, "v1.2.1"},
+		{"exact missing", "v1.2.9", Options{}, ""},
+		{"branch", "master", Options{}, "master"},
+		{"commit prefix", "abcdef1", Options{}, "v1.10.2-0.20231010123456-abcdef123456"},
+		{"unknown revision", "deadbeef", Options{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve("github.com/example/dep", tt.query, testVersions, tt.opts)
+			if tt.expected == "" {
+				if !errors.Is(err, ErrNoMatchingVersion) {
+					t.Errorf("Resolve(%q) = %q, %v, want ErrNoMatchingVersion", tt.query, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve(%q) returned error: %v", tt.query, err)
+			}
+			if got != tt.expected {
+				t.Errorf("Resolve(%q) = %q, want %q", tt.query, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolve_ExcludesAndRetracts(t *testing.T) {
+	mod := &module.Module{
+		Name: "github.com/example/main",
+		Excludes: []*module.Exclude{
+			{Path: "github.com/example/dep", Version: "v2.0.0+incompatible"},
+			{Path: "github.com/example/dep", Version: "v1.2.2"},
+			{Path: "github.com/example/other", Version: "v1.10.0"},
+		},
+	}
+	retracts := []*module.Retract{
+		{VersionLow: "v1.9.0", VersionHigh: "v1.10.0"},
+		{Version: "v1.2.1"},
+	}
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"latest", "v1.10.0"},
+		{"patch", "v1.2.1"},
+		{"v1.2.2", ""},
+	}
+	for _, tt := range tests {
+		got, _ := Resolve("github.com/example/dep", tt.query, testVersions, Options{Current: "v1.2.0", Module: mod})
+		if got != tt.expected {
+			t.Errorf("Resolve(%q) with excludes = %q, want %q", tt.query, got, tt.expected)
+		}
+	}
+
+	opts := Options{Current: "v1.2.0", Module: mod, Retracts: retracts}
+	tests = []struct {
+		query    string
+		expected string
+	}{
+		{"latest", "v1.2.0"},
+		{"patch", "v1.2.0"},
+		{"v1.2.1", "v1.2.1"}, // 明确指定的版本即使已撤回也可以选中
+		{">v1.2.0", "v1.3.0-rc.1"},
+	}
+	for _, tt := range tests {
+		got, err := Resolve("github.com/example/dep", tt.query, testVersions, opts)
+		if err != nil || got != tt.expected {
+			t.Errorf("Resolve(%q) with retracts = %q, %v, want %q", tt.query, got, err, tt.expected)
+		}
+	}
+
+	// 主模块自身的retract只对主模块的版本生效
+	mod.Retracts = retracts
+	got, err := Resolve("github.com/example/main", "latest", testVersions, Options{Module: mod})
+	if err != nil || got != "v2.0.0+incompatible" {
+		t.Errorf("Resolve(main, latest) = %q, %v, want v2.0.0+incompatible", got, err)
+	}
+	got, err = Resolve("github.com/example/main", "v1", testVersions, Options{Module: mod})
+	if err != nil || got != "v1.2.2" {
+		t.Errorf("Resolve(main, v1) = %q, %v, want v1.2.2", got, err)
+	}
+}
+
+func TestResolve_InvalidQuery(t *testing.T) {
+	for _, query := range []string{"", ">=", "<banana", ">=1.2.0"} {
+		if _, err := Resolve("github.com/example/dep", query, testVersions, Options{}); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("Resolve(%q) error = %v, want ErrInvalidQuery", query, err)
+		}
+	}
+}
+
+func TestResolveRequire(t *testing.T) {
+	mod := &module.Module{
+		Name:     "github.com/example/main",
+		Requires: []*module.Require{{Path: "github.com/example/dep", Version: "v1.2.0"}},
+		Excludes: []*module.Exclude{{Path: "github.com/example/dep", Version: "v1.2.2"}},
+	}
+
+	got, err := ResolveRequire(mod, mod.Requires[0], "patch", testVersions)
+	if err != nil || got != "v1.2.1" {
+		t.Errorf("ResolveRequire(patch) = %q, %v, want v1.2.1", got, err)
+	}
+}
@@ -0,0 +1,165 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidPseudoVersion 表示版本号不是合法的伪版本
+	ErrInvalidPseudoVersion = errors.New("invalid pseudo-version")
+)
+
+// PseudoTimeFormat 伪版本中时间戳的格式（UTC）
+const PseudoTimeFormat = "20060102150405"
+
+// pseudoVersionRegexp 匹配三种伪版本形式的语法：
+//
+//	vX.0.0-yyyymmddhhmmss-abcdefabcdef        没有更早的版本标签
+//	vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef  基于预发布版本 vX.Y.Z-pre
+//	vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef  基于正式版本 vX.Y.Z
+var pseudoVersionRegexp = regexp.MustCompile(`^v[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// revisionRegexp 匹配伪版本中的提交哈希：12位小写十六进制字符
+var revisionRegexp = regexp.MustCompile(`^[0-9a-f]{12}$`)
+
+// PseudoVersion 表示分解后的伪版本
+type PseudoVersion struct {
+	// Base 伪版本所基于的版本标签，没有更早的版本标签时为空
+	Base string
+
+	// Time 提交时间（UTC）
+	Time time.Time
+
+	// Revision 提交哈希的前12位
+	Revision string
+
+	// Build 构建元数据（包含开头的 +），例如 "+incompatible"
+	Build string
+}
+
+// IsPseudoVersion 检查版本号在语法上是否为伪版本
+func IsPseudoVersion(v string) bool {
+	return strings.Count(v, "-") >= 2 && IsValid(v) && pseudoVersionRegexp.MatchString(v)
+}
+
+// ParsePseudoVersion 将伪版本分解为基础版本、UTC时间和提交哈希，同时检查：
+// 时间戳是合法的UTC时间、提交哈希是12位小写十六进制字符，
+// 以及基于正式版本的形式中修订号大于0（基础版本的修订号为其减1）
+func ParsePseudoVersion(v string) (PseudoVersion, error) {
+	if !IsPseudoVersion(v) {
+		return PseudoVersion{}, fmt.Errorf("%w: %q", ErrInvalidPseudoVersion, v)
+	}
+
+	p := PseudoVersion{Build: Build(v)}
+	rest := strings.TrimSuffix(v, p.Build)
+
+	// 依次取出末尾的提交哈希和14位时间戳，剩余部分决定伪版本的形式
+	j := strings.LastIndex(rest, "-")
+	rest, p.Revision = rest[:j], rest[j+1:]
+	head, ts := rest[:len(rest)-len(PseudoTimeFormat)], rest[len(rest)-len(PseudoTimeFormat):]
+
+	if !revisionRegexp.MatchString(p.Revision) {
+		return PseudoVersion{}, fmt.Errorf("%w: %q: revision must be 12 lowercase hex characters", ErrInvalidPseudoVersion, v)
+	}
+	t, err := time.Parse(PseudoTimeFormat, ts)
+	if err != nil {
+		return PseudoVersion{}, fmt.Errorf("%w: %q: malformed timestamp", ErrInvalidPseudoVersion, v)
+	}
+	p.Time = t.UTC()
+
+	switch {
+	case strings.HasSuffix(head, "-0.") && Prerelease(head[:len(head)-3]) == "":
+		// vX.Y.(Z+1)-0.时间戳-哈希：基础版本为 vX.Y.Z
+		release, err := Parse(head[:len(head)-3])
+		if err != nil {
+			return PseudoVersion{}, fmt.Errorf("%w: %q", ErrInvalidPseudoVersion, v)
+		}
+		patch, ok := decDecimal(release.Patch)
+		if !ok {
+			return PseudoVersion{}, fmt.Errorf("%w: %q: patch version must be greater than 0", ErrInvalidPseudoVersion, v)
+		}
+		p.Base = "v" + release.Major + "." + release.Minor + "." + patch
+	case strings.HasSuffix(head, ".0."):
+		// vX.Y.Z-pre.0.时间戳-哈希：基础版本为 vX.Y.Z-pre
+		p.Base = head[:len(head)-3]
+		if Prerelease(p.Base) == "" {
+			return PseudoVersion{}, fmt.Errorf("%w: %q", ErrInvalidPseudoVersion, v)
+		}
+	default:
+		// vX.0.0-时间戳-哈希：没有更早的版本标签
+		p.Base = ""
+	}
+	return p, nil
+}
+
+// NewPseudoVersion 根据基础版本标签、提交时间和提交哈希构造伪版本
+// base为空表示没有更早的版本标签，此时使用major作为主版本（为空时为v0）；
+// base带有构建元数据（例如 +incompatible）时保留在结果中；提交哈希超过12位时截断
+func NewPseudoVersion(major, base string, t time.Time, rev string) (string, error) {
+	if len(rev) > 12 {
+		rev = rev[:12]
+	}
+	if !revisionRegexp.MatchString(rev) {
+		return "", fmt.Errorf("%w: revision %q must be at least 12 lowercase hex characters", ErrInvalidPseudoVersion, rev)
+	}
+	segment := t.UTC().Format(PseudoTimeFormat) + "-" + rev
+
+	if base == "" {
+		if major == "" {
+			major = "v0"
+		}
+		if Major(major) != major {
+			return "", fmt.Errorf("%w: invalid major version %q", ErrInvalidPseudoVersion, major)
+		}
+		return major + ".0.0-" + segment, nil
+	}
+
+	parsed, err := Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid base version %q", ErrInvalidPseudoVersion, base)
+	}
+	if IsPseudoVersion(base) {
+		return "", fmt.Errorf("%w: base version %q is itself a pseudo-version", ErrInvalidPseudoVersion, base)
+	}
+
+	build := parsed.Build
+	if parsed.Prerelease != "" {
+		return parsed.String() + ".0." + segment + build, nil
+	}
+	return "v" + parsed.Major + "." + parsed.Minor + "." + incDecimal(parsed.Patch) + "-0." + segment + build, nil
+}
+
+// incDecimal 将十进制数字字符串加1
+func incDecimal(decimal string) string {
+	digits := []byte(decimal)
+	i := len(digits) - 1
+	for ; i >= 0 && digits[i] == '9'; i-- {
+		digits[i] = '0'
+	}
+	if i >= 0 {
+		digits[i]++
+		return string(digits)
+	}
+	return "1" + string(digits)
+}
+
+// decDecimal 将十进制数字字符串减1，结果小于0时返回false
+func decDecimal(decimal string) (string, bool) {
+	if decimal == "0" {
+		return "", false
+	}
+	digits := []byte(decimal)
+	i := len(digits) - 1
+	for ; digits[i] == '0'; i-- {
+		digits[i] = '9'
+	}
+	digits[i]--
+	if digits[0] == '0' && len(digits) > 1 {
+		digits = digits[1:]
+	}
+	return string(digits), true
+}
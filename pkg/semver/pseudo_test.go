@@ -0,0 +1,161 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIsPseudoVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected bool
+	}{
+		{"v0.0.0-20231010123456-abcdef123456", true},
+		{"v2.0.0-20231010123456-abcdef123456", true},
+		{"v1.2.4-0.20231010123456-abcdef123456", true},
+		{"v1.2.3-pre.0.20231010123456-abcdef123456", true},
+		{"v2.0.1-0.20231010123456-abcdef123456+incompatible", true},
+		{"v1.2.3", false},
+		{"v1.2.3-pre", false},
+		{"v1.2.3-20231010123456-abcdef123456", false},
+		{"v0.0.0-2023101012345-abcdef123456", false},
+		{"0.0.0-20231010123456-abcdef123456", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := IsPseudoVersion(tt.version); got != tt.expected {
+				t.Errorf("IsPseudoVersion(%q) = %v, want %v", tt.version, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParsePseudoVersion(t *testing.T) {
+	ts := time.Date(2023, 10, 10, 12, 34, 56, 0, time.UTC)
+
+	tests := []struct {
+		version  string
+		expected PseudoVersion
+	}{
+		{
+			version:  "v0.0.0-20231010123456-abcdef123456",
+			expected: PseudoVersion{Time: ts, Revision: "abcdef123456"},
+		},
+		{
+			version:  "v1.2.4-0.20231010123456-abcdef123456",
+			expected: PseudoVersion{Base: "v1.2.3", Time: ts, Revision: "abcdef123456"},
+		},
+		{
+			version:  "v1.2.10-0.20231010123456-abcdef123456",
+			expected: PseudoVersion{Base: "v1.2.9", Time: ts, Revision: "abcdef123456"},
+		},
+		{
+			version:  "v1.2.3-rc.1.0.20231010123456-abcdef123456",
+			expected: PseudoVersion{Base: "v1.2.3-rc.1", Time: ts, Revision: "abcdef123456"},
+		},
+		{
+			version:  "v2.0.1-0.20231010123456-abcdef123456+incompatible",
+			expected: PseudoVersion{Base: "v2.0.0", Time: ts, Revision: "abcdef123456", Build: "+incompatible"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := ParsePseudoVersion(tt.version)
+			if err != nil {
+				t.Fatalf("ParsePseudoVersion(%q) returned error: %v", tt.version, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ParsePseudoVersion(%q) = %+v, want %+v", tt.version, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParsePseudoVersion_Invalid(t *testing.T) {
+	tests := []string{
+		"v1.2.3",
+		"v1.2.0-0.20231010123456-abcdef123456",  // 修订号为0时不存在基础版本
+		"v0.0.0-20231310123456-abcdef123456",    // 月份不合法
+		"v0.0.0-20231010123456-ABCDEF123456",    // 哈希必须为小写
+		"v0.0.0-20231010123456-abcdef12345",     // 哈希长度不足12位
+		"v1.2.4-0.20231010123456-abcdef1234567", // 哈希超过12位
+	}
+
+	for _, version := range tests {
+		t.Run(version, func(t *testing.T) {
+			_, err := ParsePseudoVersion(version)
+			if !errors.Is(err, ErrInvalidPseudoVersion) {
+				t.Errorf("ParsePseudoVersion(%q) error = %v, want ErrInvalidPseudoVersion", version, err)
+			}
+		})
+	}
+}
+
+func TestNewPseudoVersion(t *testing.T) {
+	// 非UTC时间会被转换为UTC
+	ts := time.Date(2023, 10, 10, 20, 34, 56, 0, time.FixedZone("UTC+8", 8*60*60))
+	rev := "abcdef1234567890abcdef1234567890abcdef12"
+
+	tests := []struct {
+		major    string
+		base     string
+		expected string
+	}{
+		{"", "", "v0.0.0-20231010123456-abcdef123456"},
+		{"v2", "", "v2.0.0-20231010123456-abcdef123456"},
+		{"", "v1.2.3", "v1.2.4-0.20231010123456-abcdef123456"},
+		{"", "v1.2.9", "v1.2.10-0.20231010123456-abcdef123456"},
+		{"", "v1.2.3-rc.1", "v1.2.3-rc.1.0.20231010123456-abcdef123456"},
+		{"", "v2.0.0+incompatible", "v2.0.1-0.20231010123456-abcdef123456+incompatible"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			got, err := NewPseudoVersion(tt.major, tt.base, ts, rev)
+			if err != nil {
+				t.Fatalf("NewPseudoVersion returned error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("NewPseudoVersion(%q, %q) = %q, want %q", tt.major, tt.base, got, tt.expected)
+			}
+
+			// 构造的伪版本可以分解回原来的基础版本和提交信息
+			p, err := ParsePseudoVersion(got)
+			if err != nil {
+				t.Fatalf("ParsePseudoVersion(%q) returned error: %v", got, err)
+			}
+			if p.Base != Canonical(tt.base) || !p.Time.Equal(ts) || p.Revision != rev[:12] {
+				t.Errorf("ParsePseudoVersion(%q) = %+v", got, p)
+			}
+		})
+	}
+}
+
+func TestNewPseudoVersion_Invalid(t *testing.T) {
+	ts := time.Date(2023, 10, 10, 12, 34, 56, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		major string
+		base  string
+		rev   string
+	}{
+		{"short revision", "", "", "abcdef"},
+		{"uppercase revision", "", "", "ABCDEF123456"},
+		{"invalid major", "v2.1", "", "abcdef123456"},
+		{"invalid base", "", "1.2.3", "abcdef123456"},
+		{"pseudo-version base", "", "v0.0.0-20231010123456-abcdef123456", "abcdef123456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPseudoVersion(tt.major, tt.base, ts, tt.rev)
+			if !errors.Is(err, ErrInvalidPseudoVersion) {
+				t.Errorf("NewPseudoVersion error = %v, want ErrInvalidPseudoVersion", err)
+			}
+		})
+	}
+}
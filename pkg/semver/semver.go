@@ -0,0 +1,347 @@
+// Package semver 实现Go模块使用的语义化版本（semver 2.0）
+//
+// 版本号必须以 v 开头，例如 v1.2.3、v1.2.3-pre.1、v1.2.3+meta。
+// 与Go命令一致，允许 v1、v1.2 这样的简写形式，分别等价于 v1.0.0 和 v1.2.0，
+// 但简写形式不能带预发布标识或构建元数据。
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrInvalidVersion 表示版本号不是合法的语义化版本
+	ErrInvalidVersion = errors.New("invalid semantic version")
+)
+
+// Version 表示解析后的语义化版本
+type Version struct {
+	// Major 主版本号，例如 v1.2.3 中的 "1"
+	Major string
+
+	// Minor 次版本号，简写形式中缺省时为 "0"
+	Minor string
+
+	// Patch 修订号，简写形式中缺省时为 "0"
+	Patch string
+
+	// Prerelease 预发布标识，包含开头的 -，例如 "-rc.1"
+	Prerelease string
+
+	// Build 构建元数据，包含开头的 +，例如 "+incompatible"
+	Build string
+
+	// short 简写形式缺省的部分，例如 v1.2 对应 ".0"
+	short string
+}
+
+// String 返回版本的规范形式，不包含构建元数据
+func (v Version) String() string {
+	return "v" + v.Major + "." + v.Minor + "." + v.Patch + v.Prerelease
+}
+
+// Parse 解析语义化版本
+func Parse(v string) (Version, error) {
+	p, ok := parse(v)
+	if !ok {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, v)
+	}
+	return p, nil
+}
+
+// IsValid 检查版本号是否为合法的语义化版本
+func IsValid(v string) bool {
+	_, ok := parse(v)
+	return ok
+}
+
+// Canonical 返回版本号的规范形式：补全简写形式中缺省的部分并去掉构建元数据
+// 两个版本号只有在规范形式相同时才相等；不合法的版本号返回空字符串
+func Canonical(v string) string {
+	p, ok := parse(v)
+	if !ok {
+		return ""
+	}
+	if p.Build != "" {
+		return v[:len(v)-len(p.Build)]
+	}
+	return v + p.short
+}
+
+// Major 返回版本号的主版本部分，例如 v2.1.0 返回 "v2"；不合法的版本号返回空字符串
+func Major(v string) string {
+	p, ok := parse(v)
+	if !ok {
+		return ""
+	}
+	return "v" + p.Major
+}
+
+// MajorMinor 返回版本号的主版本和次版本部分，例如 v2.1.0 返回 "v2.1"；
+// 不合法的版本号返回空字符串
+func MajorMinor(v string) string {
+	p, ok := parse(v)
+	if !ok {
+		return ""
+	}
+	return "v" + p.Major + "." + p.Minor
+}
+
+// Prerelease 返回版本号的预发布标识（包含开头的 -），没有时返回空字符串
+func Prerelease(v string) string {
+	p, ok := parse(v)
+	if !ok {
+		return ""
+	}
+	return p.Prerelease
+}
+
+// Build 返回版本号的构建元数据（包含开头的 +），没有时返回空字符串
+func Build(v string) string {
+	p, ok := parse(v)
+	if !ok {
+		return ""
+	}
+	return p.Build
+}
+
+// Compare 比较两个版本号，v小于、等于、大于w时分别返回-1、0、1
+// 构建元数据不参与比较；不合法的版本号小于所有合法的版本号，相互之间视为相等
+func Compare(v, w string) int {
+	pv, okV := parse(v)
+	pw, okW := parse(w)
+	switch {
+	case !okV && !okW:
+		return 0
+	case !okV:
+		return -1
+	case !okW:
+		return 1
+	}
+
+	if c := compareInt(pv.Major, pw.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(pv.Minor, pw.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(pv.Patch, pw.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(pv.Prerelease, pw.Prerelease)
+}
+
+// Max 返回两个版本号中较大的一个，相等时返回v
+// 只有一个版本号合法时返回合法的那个，都不合法时返回空字符串
+func Max(v, w string) string {
+	if !IsValid(v) && !IsValid(w) {
+		return ""
+	}
+	if Compare(v, w) < 0 {
+		return w
+	}
+	return v
+}
+
+// Sort 将版本号列表从小到大排序，比较结果相等的版本号按字符串排序
+func Sort(list []string) {
+	sort.Slice(list, func(i, j int) bool {
+		if c := Compare(list[i], list[j]); c != 0 {
+			return c < 0
+		}
+		return list[i] < list[j]
+	})
+}
+
+// parse 解析版本号，格式不合法时返回false
+func parse(v string) (p Version, ok bool) {
+	if v == "" || v[0] != 'v' {
+		return
+	}
+	if p.Major, v, ok = parseInt(v[1:]); !ok {
+		return
+	}
+	if v == "" {
+		p.Minor, p.Patch, p.short = "0", "0", ".0.0"
+		return
+	}
+	if v[0] != '.' {
+		ok = false
+		return
+	}
+	if p.Minor, v, ok = parseInt(v[1:]); !ok {
+		return
+	}
+	if v == "" {
+		p.Patch, p.short = "0", ".0"
+		return
+	}
+	if v[0] != '.' {
+		ok = false
+		return
+	}
+	if p.Patch, v, ok = parseInt(v[1:]); !ok {
+		return
+	}
+	if len(v) > 0 && v[0] == '-' {
+		if p.Prerelease, v, ok = parsePrerelease(v); !ok {
+			return
+		}
+	}
+	if len(v) > 0 && v[0] == '+' {
+		if p.Build, v, ok = parseBuild(v); !ok {
+			return
+		}
+	}
+	if v != "" {
+		ok = false
+	}
+	return
+}
+
+// parseInt 解析开头的十进制数字，除0本身以外不允许前导0
+func parseInt(v string) (t, rest string, ok bool) {
+	if v == "" || v[0] < '0' || v[0] > '9' {
+		return
+	}
+	i := 1
+	for i < len(v) && '0' <= v[i] && v[i] <= '9' {
+		i++
+	}
+	if v[0] == '0' && i != 1 {
+		return
+	}
+	return v[:i], v[i:], true
+}
+
+// parsePrerelease 解析开头的预发布标识，由 . 分隔的标识符组成，
+// 纯数字的标识符不允许前导0
+func parsePrerelease(v string) (t, rest string, ok bool) {
+	i := 1
+	start := 1
+	for i < len(v) && v[i] != '+' {
+		if !isIdentChar(v[i]) && v[i] != '.' {
+			return
+		}
+		if v[i] == '.' {
+			if start == i || isBadNum(v[start:i]) {
+				return
+			}
+			start = i + 1
+		}
+		i++
+	}
+	if start == i || isBadNum(v[start:i]) {
+		return
+	}
+	return v[:i], v[i:], true
+}
+
+// parseBuild 解析构建元数据，由 . 分隔的非空标识符组成
+func parseBuild(v string) (t, rest string, ok bool) {
+	i := 1
+	start := 1
+	for i < len(v) {
+		if !isIdentChar(v[i]) && v[i] != '.' {
+			return
+		}
+		if v[i] == '.' {
+			if start == i {
+				return
+			}
+			start = i + 1
+		}
+		i++
+	}
+	if start == i {
+		return
+	}
+	return v[:i], v[i:], true
+}
+
+// isIdentChar 检查字符是否可以出现在预发布标识或构建元数据的标识符中
+func isIdentChar(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-'
+}
+
+// isBadNum 检查标识符是否为带前导0的数字
+func isBadNum(v string) bool {
+	return isNum(v) && len(v) > 1 && v[0] == '0'
+}
+
+// isNum 检查标识符是否只由数字组成
+func isNum(v string) bool {
+	if v == "" {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		if v[i] < '0' || v[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// compareInt 比较两个不带前导0的十进制数字字符串
+func compareInt(x, y string) int {
+	if x == y {
+		return 0
+	}
+	if len(x) < len(y) {
+		return -1
+	}
+	if len(x) > len(y) {
+		return 1
+	}
+	if x < y {
+		return -1
+	}
+	return 1
+}
+
+// comparePrerelease 比较两个预发布标识
+// 没有预发布标识的版本大于有预发布标识的版本；标识符逐个比较，
+// 数字标识符按数值比较且小于非数字标识符，其余按ASCII顺序比较，前缀相同时较短的较小
+func comparePrerelease(x, y string) int {
+	if x == y {
+		return 0
+	}
+	if x == "" {
+		return 1
+	}
+	if y == "" {
+		return -1
+	}
+
+	xs := strings.Split(x[1:], ".")
+	ys := strings.Split(y[1:], ".")
+	for i := 0; i < len(xs) && i < len(ys); i++ {
+		dx, dy := xs[i], ys[i]
+		if dx == dy {
+			continue
+		}
+		numX, numY := isNum(dx), isNum(dy)
+		switch {
+		case numX && numY:
+			return compareInt(dx, dy)
+		case numX:
+			return -1
+		case numY:
+			return 1
+		case dx < dy:
+			return -1
+		default:
+			return 1
+		}
+	}
+	switch {
+	case len(xs) < len(ys):
+		return -1
+	case len(xs) > len(ys):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,165 @@
+package semver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected Version
+		valid    bool
+	}{
+		{"v1.2.3", Version{Major: "1", Minor: "2", Patch: "3"}, true},
+		{"v1.2.3-rc.1+meta", Version{Major: "1", Minor: "2", Patch: "3", Prerelease: "-rc.1", Build: "+meta"}, true},
+		{"v2.0.0+incompatible", Version{Major: "2", Minor: "0", Patch: "0", Build: "+incompatible"}, true},
+		{"v1.2", Version{Major: "1", Minor: "2", Patch: "0", short: ".0"}, true},
+		{"v1", Version{Major: "1", Minor: "0", Patch: "0", short: ".0.0"}, true},
+		{"1.2.3", Version{}, false},
+		{"v1.2.3.4", Version{}, false},
+		{"v01.2.3", Version{}, false},
+		{"v1.2-pre", Version{}, false},
+		{"v1.2.3-", Version{}, false},
+		{"v1.2.3-01", Version{}, false},
+		{"v1.2.3-a..b", Version{}, false},
+		{"v1.2.3+", Version{}, false},
+		{"v1.2.3+a_b", Version{}, false},
+		{"", Version{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := Parse(tt.version)
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("Parse(%q) returned error: %v", tt.version, err)
+				}
+				if !reflect.DeepEqual(got, tt.expected) {
+					t.Errorf("Parse(%q) = %+v, want %+v", tt.version, got, tt.expected)
+				}
+			} else if !errors.Is(err, ErrInvalidVersion) {
+				t.Errorf("Parse(%q) error = %v, want ErrInvalidVersion", tt.version, err)
+			}
+			if IsValid(tt.version) != tt.valid {
+				t.Errorf("IsValid(%q) = %v, want %v", tt.version, !tt.valid, tt.valid)
+			}
+		})
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	tests := []struct {
+		version    string
+		canonical  string
+		major      string
+		majorMinor string
+		prerelease string
+		build      string
+	}{
+		{"v1.2.3", "v1.2.3", "v1", "v1.2", "", ""},
+		{"v1.2", "v1.2.0", "v1", "v1.2", "", ""},
+		{"v2", "v2.0.0", "v2", "v2.0", "", ""},
+		{"v1.2.3-beta.2+build.7", "v1.2.3-beta.2", "v1", "v1.2", "-beta.2", "+build.7"},
+		{"v3.0.0+incompatible", "v3.0.0", "v3", "v3.0", "", "+incompatible"},
+		{"bad", "", "", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := Canonical(tt.version); got != tt.canonical {
+				t.Errorf("Canonical(%q) = %q, want %q", tt.version, got, tt.canonical)
+			}
+			if got := Major(tt.version); got != tt.major {
+				t.Errorf("Major(%q) = %q, want %q", tt.version, got, tt.major)
+			}
+			if got := MajorMinor(tt.version); got != tt.majorMinor {
+				t.Errorf("MajorMinor(%q) = %q, want %q", tt.version, got, tt.majorMinor)
+			}
+			if got := Prerelease(tt.version); got != tt.prerelease {
+				t.Errorf("Prerelease(%q) = %q, want %q", tt.version, got, tt.prerelease)
+			}
+			if got := Build(tt.version); got != tt.build {
+				t.Errorf("Build(%q) = %q, want %q", tt.version, got, tt.build)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// 按照从小到大的顺序排列，相邻的两项满足前者小于后者
+	ordered := []string{
+		"bad",
+		"v0.0.0",
+		"v0.0.1-0",
+		"v0.0.1-alpha",
+		"v0.0.1-alpha.1",
+		"v0.0.1-alpha.beta",
+		"v0.0.1-beta",
+		"v0.0.1-beta.2",
+		"v0.0.1-beta.11",
+		"v0.0.1-rc.1",
+		"v0.0.1",
+		"v1.9.0",
+		"v1.10.0",
+		"v2.0.0+incompatible",
+		"v10.0.0",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := Compare(ordered[i], ordered[j]); got != want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+
+	equal := [][2]string{
+		{"v1", "v1.0.0"},
+		{"v1.2", "v1.2.0"},
+		{"v1.2.3+a", "v1.2.3+b"},
+		{"bad", "worse"},
+	}
+	for _, pair := range equal {
+		if got := Compare(pair[0], pair[1]); got != 0 {
+			t.Errorf("Compare(%q, %q) = %d, want 0", pair[0], pair[1], got)
+		}
+	}
+}
+
+func TestMax(t *testing.T) {
+	tests := []struct {
+		v, w     string
+		expected string
+	}{
+		{"v1.9.0", "v1.10.0", "v1.10.0"},
+		{"v1.10.0", "v1.9.0", "v1.10.0"},
+		{"v1.0.0", "bad", "v1.0.0"},
+		{"bad", "v1.0.0", "v1.0.0"},
+		{"bad", "worse", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Max(tt.v, tt.w); got != tt.expected {
+			t.Errorf("Max(%q, %q) = %q, want %q", tt.v, tt.w, got, tt.expected)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	list := []string{"v1.10.0", "v1.2.0", "v1.2.0-rc.1", "bad", "v1.2", "v0.9.9"}
+	Sort(list)
+
+	expected := []string{"bad", "v0.9.9", "v1.2.0-rc.1", "v1.2", "v1.2.0", "v1.10.0"}
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("Sort() = %v, want %v", list, expected)
+	}
+}
@@ -9,6 +9,8 @@ import (
 var (
 	// ErrGoModNotFound 表示在当前目录及父目录中未找到go.mod文件
 	ErrGoModNotFound = errors.New("go.mod file not found")
+	// ErrGoSumNotFound 表示模块根目录中没有go.sum文件
+	ErrGoSumNotFound = errors.New("go.sum file not found")
 )
 
 // FindGoModFile 在指定目录及其父目录中查找go.mod文件
@@ -43,6 +45,21 @@ func FindGoModFile(dir string) (string, error) {
 	return "", ErrGoModNotFound
 }
 
+// FindGoSumFile 在指定目录及其父目录中查找go.mod文件，返回与其位于同一目录的go.sum文件
+// 没有找到go.mod时返回ErrGoModNotFound，模块根目录中没有go.sum时返回ErrGoSumNotFound
+func FindGoSumFile(dir string) (string, error) {
+	goModPath, err := FindGoModFile(dir)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(filepath.Dir(goModPath), "go.sum")
+	if !IsFile(path) {
+		return "", ErrGoSumNotFound
+	}
+	return path, nil
+}
+
 // IsFile 检查指定路径是否是文件
 func IsFile(path string) bool {
 	info, err := os.Stat(path)
@@ -194,3 +194,31 @@ func TestExists(t *testing.T) {
 		t.Errorf("Exists for nonexistent path = true, want false")
 	}
 }
+
+func TestFindGoSumFile(t *testing.T) {
+	tempDir := t.TempDir()
+	subDir := filepath.Join(tempDir, "pkg", "sub")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatalf("Failed to create test directory structure: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte("module example.com/test"), 0644); err != nil {
+		t.Fatalf("Failed to write test go.mod file: %v", err)
+	}
+
+	// 模块根目录中没有go.sum
+	if _, err := FindGoSumFile(subDir); err != ErrGoSumNotFound {
+		t.Errorf("Expected ErrGoSumNotFound, got %v", err)
+	}
+
+	goSumPath := filepath.Join(tempDir, "go.sum")
+	if err := os.WriteFile(goSumPath, nil, 0644); err != nil {
+		t.Fatalf("Failed to write test go.sum file: %v", err)
+	}
+	found, err := FindGoSumFile(subDir)
+	if err != nil {
+		t.Fatalf("FindGoSumFile failed: %v", err)
+	}
+	if found != goSumPath {
+		t.Errorf("Expected to find %q, got %q", goSumPath, found)
+	}
+}